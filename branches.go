@@ -0,0 +1,79 @@
+package main
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// branchesConfName is the name of the conf file section that overrides rule
+// settings for different branches.
+const branchesConfName = "branches"
+
+// confForBranch returns conf with the overrides for every branch pattern that
+// matches branch applied. Patterns use the same syntax as path.Match, so "*"
+// doesn't match a "/". When several patterns match, the most specific pattern
+// is applied last so that its settings take precedence. If a matching pattern
+// is set to false, then skip is true and no rules should be checked.
+func confForBranch(conf map[string]interface{}, branch string) (
+	branchConf map[string]interface{}, skip bool) {
+	patterns, ok := conf[branchesConfName].(map[string]interface{})
+	if !ok || branch == "" {
+		return conf, false
+	}
+
+	var matches []string
+	for pattern := range patterns {
+		if matched, _ := path.Match(pattern, branch); matched {
+			matches = append(matches, pattern)
+		}
+	}
+	for _, pattern := range sortBySpecificity(matches) {
+		switch override := patterns[pattern].(type) {
+		case bool:
+			if !override {
+				return conf, true
+			}
+		case map[string]interface{}:
+			conf = mergeConf(conf, override)
+		}
+	}
+	return conf, false
+}
+
+// sortBySpecificity sorts glob patterns from least to most specific. Patterns
+// that are equally specific are sorted alphabetically so that the order is
+// stable.
+func sortBySpecificity(patterns []string) []string {
+	sort.Slice(patterns, func(i, j int) bool {
+		si, sj := specificity(patterns[i]), specificity(patterns[j])
+		if si != sj {
+			return si < sj
+		}
+		return patterns[i] < patterns[j]
+	})
+	return patterns
+}
+
+// specificity ranks how specific a glob pattern is. Patterns without any
+// wildcards match a single branch or path, so they rank above every other
+// pattern. Otherwise, patterns with more literal characters rank higher.
+func specificity(pattern string) int {
+	if !strings.ContainsAny(pattern, `*?[\`) {
+		return len(pattern) + 1<<16
+	}
+
+	literal := 0
+	inClass := false
+	for _, r := range pattern {
+		switch {
+		case r == '[':
+			inClass = true
+		case r == ']':
+			inClass = false
+		case !inClass && r != '*' && r != '?':
+			literal++
+		}
+	}
+	return literal
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+)
+
+// defaultCommentChar is the string git uses for commenting out lines in commit
+// messages when core.commentChar isn't set.
+const defaultCommentChar = "#"
+
+// autoCommentChars are the characters that git chooses from when
+// core.commentChar is set to "auto".
+const autoCommentChars = "#;@!$%^&|:"
+
+// autoCommentMarkers are lines that git adds to a commit message after the
+// comment character. They're used to figure out which character git picked when
+// core.commentChar is set to "auto".
+var autoCommentMarkers = []string{
+	" Please enter the commit message",
+	" " + snipLine,
+}
+
+// cleanup describes how git will clean up a commit message before storing it.
+type cleanup struct {
+	mode        string // mode is one of git's commit.cleanup modes.
+	commentChar string // commentChar starts a commented-out line.
+}
+
+// readCleanup reads the commit.cleanup and core.commentChar settings from git's
+// config. The message is needed to figure out which comment character git
+// picked if core.commentChar is "auto". Defaults are used for any settings that
+// can't be read.
+func readCleanup(msg string) cleanup {
+	c := cleanup{mode: "default", commentChar: defaultCommentChar}
+	if mode, err := git("config", "--get", "commit.cleanup"); err == nil &&
+		mode != "" {
+		c.mode = mode
+	}
+
+	if char, err := git("config", "--get", "core.commentChar"); err == nil &&
+		char != "" {
+		if char == "auto" {
+			c.commentChar = detectCommentChar(msg)
+		} else {
+			c.commentChar = char
+		}
+	}
+	return c
+}
+
+// detectCommentChar returns the comment character that git picked for a
+// message when core.commentChar is "auto" by looking for the comments that git
+// adds to the message. If none are found, then the default is returned.
+func detectCommentChar(msg string) string {
+	for _, line := range strings.Split(msg, "\n") {
+		if line == "" || !strings.ContainsRune(autoCommentChars, rune(line[0])) {
+			continue
+		}
+
+		for _, marker := range autoCommentMarkers {
+			if strings.HasPrefix(line[1:], marker) {
+				return line[:1]
+			}
+		}
+	}
+	return defaultCommentChar
+}
+
+// stripComments returns true if git will remove commented-out lines from a
+// message. The commit-msg hook normally runs after the user has edited the
+// message, so the "default" mode is treated the same as "strip".
+func (c cleanup) stripComments() bool {
+	return c.mode == "strip" || c.mode == "default" || c.mode == ""
+}
+
+// cutLine returns the line that git uses to mark where the rest of a message
+// should be cut off.
+func (c cleanup) cutLine() string {
+	return c.commentChar + " " + snipLine
+}
+
+// cutIndex returns the index of the cut line in a message, or the length of the
+// message if it doesn't have one.
+func (c cleanup) cutIndex(msg string) int {
+	i := 0
+	for _, line := range strings.SplitAfter(msg, "\n") {
+		if strings.TrimSuffix(line, "\n") == c.cutLine() {
+			return i
+		}
+		i += len(line)
+	}
+	return len(msg)
+}
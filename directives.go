@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gcurtis/commitfmt/rules"
+)
+
+// Names of the directives that can be written in commented-out lines of a
+// commit message.
+const (
+	disableDirective         = "commitfmt-disable"
+	disableNextLineDirective = "commitfmt-disable-next-line"
+)
+
+// disableTrailer is the key of a trailer that disables rules for the entire
+// message, like disableDirective. Unlike comments, trailers are kept when git
+// cleans up the message, so they're also honored by the server hooks.
+const disableTrailer = "Commitfmt-Disable"
+
+// directivesConfName is the name of the conf file section that configures
+// directives.
+const directivesConfName = "directives"
+
+// reasonSep separates the rules in a directive from the reason they're being
+// disabled.
+const reasonSep = "--"
+
+// directive disables one or more rules for a single commit message. Directives
+// are written as comments or trailers in the message, for example:
+//
+//	# commitfmt-disable body-len -- The body contains a long URL.
+//	# commitfmt-disable-next-line subj-sentence-case
+//	Commitfmt-Disable: body-len -- The body contains a long URL.
+type directive struct {
+	text     string   // text is the directive as it was written.
+	rules    []string // rules are the names of the rules to disable.
+	reason   string   // reason explains why the rules are disabled.
+	nextLine bool     // nextLine limits the directive to the following line.
+	line     int      // line is the cleaned line number a nextLine applies to.
+}
+
+// suppression is a rule that was disabled by a directive.
+type suppression struct {
+	rule   string // rule is the name of the disabled rule.
+	reason string // reason explains why the rule was disabled.
+}
+
+// parseDirectives returns every directive found in the commented-out lines of
+// a raw commit message. It must be called before the message is cleaned since
+// cleaning removes comments.
+func parseDirectives(msg string, c cleanup) []directive {
+	var directives []directive
+	lines := strings.Split(msg[:c.cutIndex(msg)], "\n")
+	numbers := cleanedLineNums(lines, c)
+	for i, line := range lines {
+		if !strings.HasPrefix(line, c.commentChar) {
+			continue
+		}
+
+		fields := strings.Fields(line[len(c.commentChar):])
+		if len(fields) == 0 {
+			continue
+		}
+
+		d := directive{text: strings.TrimSpace(line[len(c.commentChar):])}
+		switch fields[0] {
+		case disableDirective:
+		case disableNextLineDirective:
+			d.nextLine = true
+			d.line = nextLine(lines[i+1:], numbers[i+1:], c)
+		default:
+			continue
+		}
+
+		d.parseArgs(strings.Join(fields[1:], " "))
+		directives = append(directives, d)
+	}
+	return directives
+}
+
+// parseTrailerDirectives returns a directive for every disable trailer in the
+// last paragraph of a cleaned commit message.
+func parseTrailerDirectives(cleanMsg string) []directive {
+	paragraphs := strings.Split(strings.TrimSpace(cleanMsg), "\n\n")
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	var directives []directive
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		sep := strings.Index(line, ":")
+		if sep == -1 || !strings.EqualFold(line[:sep], disableTrailer) {
+			continue
+		}
+
+		d := directive{text: line}
+		d.parseArgs(line[sep+1:])
+		directives = append(directives, d)
+	}
+	return directives
+}
+
+// parseArgs reads the names of the rules to disable and the optional reason
+// that follow a directive's name.
+func (d *directive) parseArgs(args string) {
+	if sep := strings.Index(args, reasonSep); sep != -1 {
+		d.reason = strings.TrimSpace(args[sep+len(reasonSep):])
+		args = args[:sep]
+	}
+	d.rules = strings.FieldsFunc(args, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+// nextLine returns the cleaned line number of the first line that isn't blank
+// or commented-out, or 0 if there isn't one. numbers are the cleaned line
+// numbers of lines.
+func nextLine(lines []string, numbers []int, c cleanup) int {
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line != "" && !strings.HasPrefix(line, c.commentChar) {
+			return numbers[i]
+		}
+	}
+	return 0
+}
+
+// cleanedLineNums returns the line number that each line of a raw message
+// will have once the message is cleaned, starting from 1, or 0 for lines that
+// cleanMsg removes. It must be kept in sync with cleanMsg.
+func cleanedLineNums(lines []string, c cleanup) []int {
+	numbers := make([]int, len(lines))
+	num, empty := 0, 0
+	for i, line := range lines {
+		if c.mode == "verbatim" {
+			numbers[i] = i + 1
+			continue
+		}
+		if c.stripComments() && strings.HasPrefix(line, c.commentChar) {
+			continue
+		}
+		if strings.TrimRight(line, " \t\r") == "" {
+			empty++
+			continue
+		}
+
+		if empty > 0 && num > 0 {
+			num++
+		}
+		empty = 0
+		num++
+		numbers[i] = num
+	}
+	return numbers
+}
+
+// readRequireReason returns true if the conf file requires directives to
+// explain why they disable a rule.
+func readRequireReason(conf map[string]interface{}) bool {
+	settings, ok := conf[directivesConfName].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	requireReason, _ := settings["require-reason"].(bool)
+	return requireReason
+}
+
+// suppress removes any violations of rules that were disabled by directives
+// and records which rules were disabled. Directives that are missing a reason
+// when one is required are ignored, as are the names of rules that don't exist.
+func (rep *report) suppress(directives []directive, requireReason bool) {
+	for _, d := range directives {
+		if requireReason && d.reason == "" {
+			rep.notes = append(rep.notes, fmt.Sprintf(
+				`"%s" was ignored because it doesn't give a reason after "%s".`,
+				d.text, reasonSep))
+			continue
+		}
+
+		for _, name := range d.rules {
+			if findRule(name) == nil {
+				rep.notes = append(rep.notes, fmt.Sprintf(
+					`"%s" was ignored because there isn't a rule named "%s".`,
+					d.text, name))
+				continue
+			}
+			rep.suppressed = append(rep.suppressed, suppression{name, d.reason})
+		}
+
+		var kept []rules.Violation
+		for _, v := range rep.violations {
+			if !d.disables(v.Rule.Name()) ||
+				(d.nextLine && rep.lineNum(v.Pos) != d.line) {
+				kept = append(kept, v)
+			}
+		}
+		rep.violations = kept
+	}
+}
+
+// disables returns true if the directive disables a rule.
+func (d directive) disables(rule string) bool {
+	for _, name := range d.rules {
+		if name == rule {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gcurtis/commitfmt/rules"
+)
+
+//go:generate go run . docs docs/rules.md.tmpl docs/rules.md
+
+// docsCategories are the categories that rules are grouped into in the
+// documentation, along with their headings.
+var docsCategories = []struct {
+	name    string
+	heading string
+}{
+	{rules.CategorySubject, "Subject"},
+	{rules.CategoryBody, "Body"},
+	{rules.CategoryGeneral, "General"},
+}
+
+// docs renders the Markdown template at args[0] with the documentation for
+// every rule and writes it to args[1].
+func docs(args []string) {
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "You must provide a path to a template and "+
+			"a path to write the documentation to.")
+		os.Exit(1)
+	}
+
+	tmpl, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't open file \"%s\".\n", args[0])
+		os.Exit(1)
+	}
+
+	rendered, err := renderDocs(string(tmpl))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't render template \"%s\": %s.\n",
+			args[0], err)
+		os.Exit(1)
+	}
+
+	err = ioutil.WriteFile(args[1], []byte(rendered), 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't write file \"%s\".\n", args[1])
+		os.Exit(1)
+	}
+}
+
+// renderDocs executes a Markdown template. The template can use {{.Rules}} to
+// insert the documentation for every rule, grouped by category.
+func renderDocs(tmpl string) (string, error) {
+	t, err := template.New("docs").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	err = t.Execute(&buf, struct{ Rules string }{rulesMarkdown()})
+	return buf.String(), err
+}
+
+// rulesMarkdown returns the documentation for every rule in rules.All. Rules
+// are documented with their default settings.
+func rulesMarkdown() string {
+	buf := bytes.Buffer{}
+	for _, category := range docsCategories {
+		fmt.Fprintf(&buf, "### %s\n\n", category.heading)
+		for _, rule := range rules.All {
+			if ruleCategory(rule) == category.name {
+				buf.WriteString(ruleMarkdown(rule))
+			}
+		}
+	}
+	return buf.String()
+}
+
+// ruleCategory returns the category of a rule. Rules that aren't documented
+// are put in the general category.
+func ruleCategory(rule rules.Interface) string {
+	if doc, ok := rule.(rules.Documented); ok && doc.Doc().Category != "" {
+		return doc.Doc().Category
+	}
+	return rules.CategoryGeneral
+}
+
+// ruleMarkdown returns the documentation for a single rule.
+func ruleMarkdown(rule rules.Interface) string {
+	buf := bytes.Buffer{}
+	fmt.Fprintf(&buf, "#### %s\n\n%s", rule.Name(), capitalize(rule.Desc()))
+	if opt, ok := rule.(rules.Optional); ok && opt.DisabledByDefault() {
+		buf.WriteString(" This rule is disabled by default.")
+	}
+	buf.WriteString("\n\n")
+
+	doc, ok := rule.(rules.Documented)
+	if !ok {
+		return buf.String()
+	}
+	d := doc.Doc()
+
+	if d.Details != "" {
+		fmt.Fprintf(&buf, "%s\n\n", d.Details)
+	}
+
+	if len(d.Settings) > 0 {
+		buf.WriteString("Settings:\n\n")
+		for _, s := range d.Settings {
+			fmt.Fprintf(&buf, "* \"%s\" - %s\n", s.Name, s.Desc)
+		}
+		buf.WriteRune('\n')
+	}
+
+	if d.ExampleConf != nil {
+		encoded, _ := json.Marshal(d.ExampleConf)
+		fmt.Fprintf(&buf, "The examples below assume these settings: `%s`\n\n",
+			encoded)
+	}
+	if len(d.ExampleFiles) > 0 {
+		fmt.Fprintf(&buf, "The examples below assume that these files were "+
+			"changed: `%s`\n\n", strings.Join(d.ExampleFiles, "`, `"))
+	}
+	markdownExamples(&buf, "Examples that follow this rule:", d.Good)
+	markdownExamples(&buf, "Examples that violate this rule:", d.Bad)
+	return buf.String()
+}
+
+// markdownExamples writes a list of example messages as code blocks under a
+// heading. Carriage returns are escaped so that they're visible.
+func markdownExamples(buf *bytes.Buffer, heading string, examples []string) {
+	if len(examples) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "%s\n\n", heading)
+	for _, example := range examples {
+		if example == "" {
+			buf.WriteString("* An empty message.\n\n")
+			continue
+		}
+		example = strings.Replace(example, "\r", `\r`, -1)
+		fmt.Fprintf(buf, "```\n%s\n```\n\n", example)
+	}
+}
+
+// capitalize returns a string with its first letter in uppercase.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// interactiveConfName is the name of the conf file setting that enables the
+// interactive editor.
+const interactiveConfName = "interactive"
+
+// readInteractive returns true if the conf file enables the interactive editor.
+func readInteractive(conf map[string]interface{}) bool {
+	interactive, _ := conf[interactiveConfName].(bool)
+	return interactive
+}
+
+// openTTY opens the terminal that commitfmt is attached to. Git runs hooks
+// without a terminal on stdin, so the terminal is opened directly. nil is
+// returned if there isn't a terminal, for example when committing from an IDE.
+func openTTY() *os.File {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil
+	}
+	return tty
+}
+
+// editUntilValid repeatedly opens the user's editor on the message in the file
+// at path with any violations listed as comments at the top of the message. It
+// stops when the message passes every rule, in which case the fixed message is
+// written back to path and ok is true. Otherwise it stops when the user saves
+// an empty or unchanged message or when the editor fails, and the last version
+// of the message is returned.
+func editUntilValid(path string, msg string, conf map[string]interface{},
+	c cleanup, tty *os.File) (edited string, ok bool) {
+	editor, err := git("var", "GIT_EDITOR")
+	if err != nil || editor == ":" {
+		return msg, false
+	}
+
+	for {
+		_, rep, err := lint(msg, conf, c)
+		if err != nil {
+			return msg, false
+		}
+		if !rep.failed() {
+			err := ioutil.WriteFile(path, []byte(msg), 0644)
+			return msg, err == nil
+		}
+
+		annotations := annotations(rep, c.commentChar)
+		annotated := strings.Join(annotations, "\n") + "\n" + msg
+		err = ioutil.WriteFile(path, []byte(annotated), 0644)
+		if err != nil {
+			return msg, false
+		}
+
+		cmd := exec.Command("sh", "-c", editor+` "$@"`, editor, path)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = tty, tty, tty
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "There was a problem with the editor "+
+				"\"%s\".\n", editor)
+			return msg, false
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if err != nil || string(b) == annotated {
+			return msg, false
+		}
+		msg = stripAnnotations(string(b), annotations)
+		if cleanMsg(msg, c) == "" {
+			return msg, false
+		}
+	}
+}
+
+// annotations returns the lines of the comment block that lists a report's
+// violations in the editor.
+func annotations(rep *report, commentChar string) []string {
+	rep.color = false
+	lines := []string{
+		fmt.Sprintf("%s commitfmt found %d formatting errors in this message. "+
+			"Fix them, then", commentChar, rep.errors()),
+		fmt.Sprintf("%s save and close the editor to check the message again. "+
+			"Save an empty", commentChar),
+		fmt.Sprintf("%s message to abort the commit.", commentChar),
+	}
+	for _, v := range rep.violations {
+		lines = append(lines, fmt.Sprintf("%s   %s", commentChar, rep.header(v)))
+	}
+	return append(lines, commentChar)
+}
+
+// stripAnnotations removes the comment block inserted by editUntilValid from
+// an edited message. Each annotation is removed once so that identical lines
+// written by the user are kept. Removing the annotations explicitly, rather
+// than relying on git to strip comments, keeps the message intact when the
+// cleanup mode doesn't strip comments.
+func stripAnnotations(msg string, annotations []string) string {
+	remaining := make(map[string]int)
+	for _, a := range annotations {
+		remaining[a]++
+	}
+
+	buf := bytes.Buffer{}
+	for _, line := range strings.SplitAfter(msg, "\n") {
+		trimmed := strings.TrimRight(line, "\r\n")
+		if remaining[trimmed] > 0 && line != "" {
+			remaining[trimmed]--
+			continue
+		}
+		buf.WriteString(line)
+	}
+	return buf.String()
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gcurtis/commitfmt/rules"
+)
+
+// listRules prints a summary of every rule, including whether it's enabled and
+// its current description.
+func listRules() {
+	conf := readConf()
+	enabled, err := configure(conf)
+	exitOnConfError(err)
+	for i, rule := range rules.All {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Print(summarizeRule(rule, enabled))
+	}
+}
+
+// explain prints the full documentation for the rule named in args[0].
+func explain(args []string) {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "You must provide the name of a rule to "+
+			"explain.")
+		os.Exit(1)
+	}
+
+	rule := findRule(args[0])
+	if rule == nil {
+		fmt.Fprintf(os.Stderr, "There isn't a rule named \"%s\". Run "+
+			"\"commitfmt rules\" to see every rule.\n", args[0])
+		os.Exit(1)
+	}
+
+	conf := readConf()
+	enabled, err := configure(conf)
+	exitOnConfError(err)
+	fmt.Print(explainRule(rule, conf, enabled))
+}
+
+// findRule returns the rule with the given name, or nil if there isn't one.
+func findRule(name string) rules.Interface {
+	for _, rule := range rules.All {
+		if rule.Name() == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// summarizeRule returns the rule's name, whether it's enabled, its description
+// and the names of its settings.
+func summarizeRule(rule rules.Interface, enabled []rules.Interface) string {
+	buf := bytes.Buffer{}
+	fmt.Fprintf(&buf, "%s (%s)\n\t%s\n", rule.Name(), ruleState(rule, enabled),
+		rule.Desc())
+
+	var names []string
+	for _, s := range ruleSettings(rule) {
+		names = append(names, s.Name)
+	}
+	fmt.Fprintf(&buf, "\tSettings: %s\n", strings.Join(names, ", "))
+	return buf.String()
+}
+
+// ruleSettings returns the documentation of every setting that a rule accepts,
+// including the "severity" setting that every rule shares.
+func ruleSettings(rule rules.Interface) []rules.Setting {
+	var settings []rules.Setting
+	if doc, ok := rule.(rules.Documented); ok {
+		settings = append(settings, doc.Doc().Settings...)
+	}
+	return append(settings, severitySettingDoc)
+}
+
+// explainRule returns everything known about a rule: its name, whether it's
+// enabled, its description, its settings and any configured values, and
+// examples of messages that follow and violate it.
+func explainRule(rule rules.Interface, conf map[string]interface{},
+	enabled []rules.Interface) string {
+	buf := bytes.Buffer{}
+	fmt.Fprintf(&buf, "%s (%s)\n\t%s\n", rule.Name(), ruleState(rule, enabled),
+		rule.Desc())
+
+	doc, ok := rule.(rules.Documented)
+	if !ok {
+		return buf.String()
+	}
+	d := doc.Doc()
+
+	if d.Details != "" {
+		fmt.Fprintf(&buf, "\t%s\n", d.Details)
+	}
+
+	settings, _ := conf[rule.Name()].(map[string]interface{})
+	buf.WriteString("\nSettings:\n")
+	for _, s := range ruleSettings(rule) {
+		fmt.Fprintf(&buf, "\t%s - %s\n", s.Name, s.Desc)
+		if value, ok := settings[s.Name]; ok {
+			encoded, _ := json.Marshal(value)
+			fmt.Fprintf(&buf, "\t\tConfigured value: %s\n", encoded)
+		}
+	}
+
+	if d.ExampleConf != nil || len(d.ExampleFiles) > 0 {
+		buf.WriteRune('\n')
+	}
+	if d.ExampleConf != nil {
+		encoded, _ := json.Marshal(d.ExampleConf)
+		fmt.Fprintf(&buf, "The examples assume these settings: %s\n", encoded)
+	}
+	if len(d.ExampleFiles) > 0 {
+		fmt.Fprintf(&buf, "The examples assume that these files were changed: "+
+			"%s\n", strings.Join(d.ExampleFiles, ", "))
+	}
+	writeExamples(&buf, "Good examples", d.Good)
+	writeExamples(&buf, "Bad examples", d.Bad)
+	return buf.String()
+}
+
+// ruleState describes whether a rule is enabled.
+func ruleState(rule rules.Interface, enabled []rules.Interface) string {
+	for _, r := range enabled {
+		if r == rule {
+			return "enabled"
+		}
+	}
+	return "disabled"
+}
+
+// writeExamples writes a list of example messages under a heading. Each line of
+// a message is indented, carriage returns are escaped so that they're visible
+// and messages are separated by a blank line.
+func writeExamples(buf *bytes.Buffer, heading string, examples []string) {
+	if len(examples) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "\n%s:\n", heading)
+	for i, example := range examples {
+		if i > 0 {
+			buf.WriteRune('\n')
+		}
+		if example == "" {
+			buf.WriteString("\t(empty message)\n")
+			continue
+		}
+		example = strings.Replace(example, "\r", `\r`, -1)
+		for _, line := range strings.Split(example, "\n") {
+			if line == "" {
+				buf.WriteRune('\n')
+			} else {
+				fmt.Fprintf(buf, "\t%s\n", line)
+			}
+		}
+	}
+}
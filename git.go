@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// git runs a git command and returns its output with any surrounding whitespace
+// trimmed.
+func git(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// currentBranch returns the short name of the branch that HEAD points to. An
+// empty string is returned if HEAD is detached or if the current directory
+// isn't inside of a git repository.
+func currentBranch() string {
+	branch, err := git("symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return branch
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"regexp"
+)
+
+// kindsConfName is the name of the conf file section that overrides rule
+// settings for different kinds of messages.
+const kindsConfName = "kinds"
+
+// msgKind describes a kind of commit message that is generated by git.
+type msgKind struct {
+	name    string         // name is used to configure the kind.
+	pattern *regexp.Regexp // pattern matches the start of the message.
+
+	// prefix is true if the match isn't part of the real subject.
+	prefix bool
+}
+
+// msgKinds is a list of every kind of message that commitfmt can detect.
+var msgKinds = []msgKind{
+	{
+		name: "merge",
+		pattern: regexp.MustCompile(`^Merge (branch|branches|remote-tracking ` +
+			`branch|tag|commit|pull request) `),
+	},
+	{
+		name:    "revert",
+		pattern: regexp.MustCompile(`^Revert "`),
+	},
+	{
+		name:    "fixup",
+		pattern: regexp.MustCompile(`^(fixup! )+`),
+		prefix:  true,
+	},
+	{
+		name:    "squash",
+		pattern: regexp.MustCompile(`^(squash! )+`),
+		prefix:  true,
+	},
+	{
+		name:    "amend",
+		pattern: regexp.MustCompile(`^(amend! )+`),
+		prefix:  true,
+	},
+}
+
+// defaultKindsConf contains the default overrides for each kind of message.
+// Merges are skipped entirely and reverts are allowed to have long subjects
+// since git quotes the original subject in them.
+var defaultKindsConf = map[string]interface{}{
+	"merge": false,
+	"revert": map[string]interface{}{
+		"subj-len": false,
+	},
+}
+
+// detectKind returns the name of the kind of a cleaned message, or an empty
+// string if it's a regular message. If the kind is marked by a prefix (e.g.,
+// "fixup! "), then the length of the prefix is also returned so that the prefix
+// can be skipped when checking the message.
+func detectKind(cleanMsg string) (kind string, prefixLen int) {
+	for _, k := range msgKinds {
+		loc := k.pattern.FindStringIndex(cleanMsg)
+		if loc == nil {
+			continue
+		}
+
+		if k.prefix {
+			prefixLen = loc[1]
+		}
+		return k.name, prefixLen
+	}
+	return "", 0
+}
+
+// confForKind returns conf with any overrides for a kind of message applied. If
+// the kind has been disabled, then skip is true and no rules should be checked.
+func confForKind(conf map[string]interface{}, kind string) (
+	kindConf map[string]interface{}, skip bool) {
+	if kind == "" {
+		return conf, false
+	}
+
+	override := defaultKindsConf[kind]
+	if userKinds, ok := conf[kindsConfName].(map[string]interface{}); ok {
+		if userOverride, ok := userKinds[kind]; ok {
+			override = userOverride
+		}
+	}
+
+	switch override := override.(type) {
+	case bool:
+		return conf, !override
+	case map[string]interface{}:
+		return mergeConf(conf, override), false
+	}
+	return conf, false
+}
+
+// mergeConf returns a copy of conf with the settings in override applied to it.
+// If a rule has settings in both, then its settings are merged with the ones in
+// override taking precedence. Otherwise, override replaces the value in conf.
+func mergeConf(conf map[string]interface{},
+	override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(conf)+len(override))
+	for k, v := range conf {
+		merged[k] = v
+	}
+
+	for k, v := range override {
+		base, baseOk := merged[k].(map[string]interface{})
+		settings, settingsOk := v.(map[string]interface{})
+		if !baseOk || !settingsOk {
+			merged[k] = v
+			continue
+		}
+
+		mergedSettings := make(map[string]interface{},
+			len(base)+len(settings))
+		for name, setting := range base {
+			mergedSettings[name] = setting
+		}
+		for name, setting := range settings {
+			mergedSettings[name] = setting
+		}
+		merged[k] = mergedSettings
+	}
+	return merged
+}
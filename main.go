@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"unicode"
 
 	"github.com/gcurtis/commitfmt/rules"
 )
@@ -17,13 +18,28 @@ import (
 // rest of a commit message.
 const snipLine = "------------------------ >8 ------------------------"
 
-// commentChar is the character git uses for commenting out lines in commit
-// messages.
-const commentChar = '#'
-
 // confName is the name of the commitfmt configuration file.
 const confName = ".commitfmt"
 
+// severitySetting is the name of the setting, accepted by every rule, that
+// controls whether its violations are errors or warnings.
+const severitySetting = "severity"
+
+// Severities that a rule can have. Violations of rules with severityError fail
+// the check, which is the default. Violations of rules with severityWarning
+// are reported without failing the check.
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// severitySettingDoc documents the "severity" setting shared by every rule.
+var severitySettingDoc = rules.Setting{
+	Name: severitySetting,
+	Desc: "whether violations are an \"error\" that fails the check (the " +
+		"default) or a \"warning\" that's reported without failing it.",
+}
+
 func main() {
 	if len(os.Args) < 2 {
 		fmt.Fprintln(os.Stderr, "You must provide a path to a file containing"+
@@ -31,7 +47,29 @@ func main() {
 		os.Exit(1)
 	}
 
-	path := os.Args[1]
+	switch os.Args[1] {
+	case "prepare":
+		prepare(os.Args[2:])
+	case "rules":
+		listRules()
+	case "explain":
+		explain(os.Args[2:])
+	case "pre-receive":
+		preReceive()
+	case "update":
+		update(os.Args[2:])
+	case "restore":
+		restore(os.Args[2:])
+	case "docs":
+		docs(os.Args[2:])
+	default:
+		check(os.Args[1])
+	}
+}
+
+// check validates the commit message in the file at path. It's meant to be run
+// as git's commit-msg hook.
+func check(path string) {
 	bytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Couldn't open file \"%s\".\n", path)
@@ -40,69 +78,274 @@ func main() {
 	msg := string(bytes)
 
 	conf := readConf()
-	cleaned := cleanMsg(msg)
-	report := runRules(cleaned, conf)
-	fmt.Println(report.string())
-	if len(report.violations) > 0 {
-		// Make a best-effort to save the commit message and provide the user
-		// with some help before exiting.
-		if f, err := ioutil.TempFile("", "commitfmt"); err == nil {
-			_, err := f.WriteString(cleaned)
-			if err == nil {
-				fmt.Fprintf(os.Stderr, "\nYour commit message has been saved. "+
-					"You can edit your previous commit message with:\n"+
-					"\tgit commit -e -F %[1]s\n"+
-					"or you can bypass this check with:\n"+
-					"\tgit commit --no-verify -e -F %[1]s\n",
-					f.Name())
+	rules.Env.Branch = currentBranch()
+	rules.Env.Files, rules.Env.Lines = stagedChanges()
+	c := readCleanup(msg)
+	cleaned, report, err := lint(msg, conf, c)
+	exitOnConfError(err)
+
+	// The rules aren't configured until the message is linted, so whether the
+	// line endings should be fixed can't be known any earlier.
+	if rules.LineEndings.Fixes() && strings.Contains(msg, "\r\n") {
+		msg, _ = normalizeNewlines(msg)
+		err := ioutil.WriteFile(path, []byte(msg), 0644)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Couldn't write file \"%s\".\n", path)
+			os.Exit(1)
+		}
+		cleaned, report, err = lint(msg, conf, c)
+		exitOnConfError(err)
+	}
+	if report.failed() && readInteractive(conf) {
+		if tty := openTTY(); tty != nil {
+			msg, ok := editUntilValid(path, msg, conf, c, tty)
+			tty.Close()
+			if ok {
+				forgetRejected()
+				return
+			}
+			if cleanMsg(msg, c) == "" {
+				fmt.Fprintln(os.Stderr, "Aborting commit due to empty commit "+
+					"message.")
+				os.Exit(1)
 			}
-			f.Close()
+			cleaned, report, err = lint(msg, conf, c)
+			exitOnConfError(err)
 		}
-		os.Exit(1)
 	}
+
+	report.color = useColor()
+	fmt.Println(report.string())
+	if !report.failed() {
+		forgetRejected()
+		return
+	}
+
+	// Make a best-effort to save the commit message and provide the user with
+	// some help before exiting.
+	if saved, err := saveRejected(cleaned); err == nil {
+		fmt.Fprintf(os.Stderr, "\nYour commit message has been saved. It "+
+			"will be restored the next time you\nrun git commit if commitfmt "+
+			"is installed as a prepare-commit-msg hook. You can\nalso edit "+
+			"your previous commit message with:\n"+
+			"\tgit commit -e -F %[1]s\n"+
+			"or you can bypass this check with:\n"+
+			"\tgit commit --no-verify -e -F %[1]s\n",
+			saved)
+	}
+	os.Exit(1)
+}
+
+// lint cleans a raw commit message and checks it against every rule. Any rules
+// disabled by directives in the raw message are suppressed in the report.
+func lint(msg string, conf map[string]interface{}, c cleanup) (
+	cleaned string, rep *report, err error) {
+	cleaned = cleanMsg(msg, c)
+	rep, err = runRules(cleaned, conf)
+	if err != nil {
+		return
+	}
+	directives := append(parseDirectives(msg, c),
+		parseTrailerDirectives(cleaned)...)
+	rep.suppress(directives, readRequireReason(conf))
+	return
 }
 
 // runRules parses a cleaned commit message and then checks every rule found in
-// the rules package.
-func runRules(cleanMsg string, conf map[string]interface{}) (rep *report) {
+// the rules package. Any overrides configured for the branch and changed files
+// in rules.Env are applied first. Messages generated by git, such as merges and
+// fixups, are checked with any overrides configured for their kind. CRLF line
+// endings are normalized before the rules are checked, but the positions in the
+// report always point into the original message. An error is returned if any
+// of the rules can't be configured.
+func runRules(cleanMsg string, conf map[string]interface{}) (rep *report,
+	err error) {
 	rep = &report{msg: cleanMsg}
-	subject, body := parseMsg(cleanMsg)
+	msg, crlfs := normalizeNewlines(cleanMsg)
+	conf, skip := confForBranch(conf, rules.Env.Branch)
+	if skip {
+		return
+	}
+	conf = confForPaths(conf, rules.Env.Files)
+	// Leading whitespace is only left in the message by the "verbatim" cleanup
+	// mode. It's skipped like a kind's prefix so that positions still point
+	// into the original message.
+	start := len(msg) - len(strings.TrimLeftFunc(msg, unicode.IsSpace))
+	kind, prefixLen := detectKind(msg[start:])
+	prefixLen += start
+	conf, skip = confForKind(conf, kind)
+	if skip {
+		return
+	}
+	subject, body := parseMsg(msg[prefixLen:])
+
+	rep.warnings = warningRules(conf)
+	rules.Env.CRLF = nil
+	for _, crlf := range crlfs {
+		rules.Env.CRLF = append(rules.Env.CRLF, crlf-prefixLen)
+	}
+
+	enabled, err := configure(conf)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range enabled {
+		violations := rule.Check(subject, body)
+		for i := range violations {
+			violations[i].Pos = originalPos(violations[i].Pos+prefixLen, crlfs)
+			if violations[i].End != 0 {
+				violations[i].End = originalPos(violations[i].End+prefixLen,
+					crlfs)
+			}
+		}
+		rep.append(violations...)
+	}
 
+	return
+}
+
+// configure configures every rule in the rules package with its settings from
+// conf and returns the rules that are enabled. Any documented settings that
+// aren't in conf are reset to their defaults so that settings from a previous
+// conf don't carry over when several messages are checked. If a rule rejects
+// its settings, an error naming the rule is returned.
+func configure(conf map[string]interface{}) ([]rules.Interface, error) {
+	var enabled []rules.Interface
 	for _, rule := range rules.All {
+		var ruleConf interface{}
 		if conf != nil {
-			ruleConf, ok := conf[rule.Name()]
-			if ok && ruleConf != nil {
-				if ruleConf == false {
-					continue
-				} else {
-					rule.Config(ruleConf.(map[string]interface{}))
-				}
+			ruleConf = conf[rule.Name()]
+		}
+
+		if ruleConf == nil {
+			if opt, ok := rule.(rules.Optional); ok && opt.DisabledByDefault() {
+				continue
 			}
+		} else if ruleConf == false {
+			continue
 		}
 
-		violations := rule.Check(subject, body)
-		rep.append(violations...)
+		settings, _ := ruleConf.(map[string]interface{})
+		switch settings[severitySetting] {
+		case nil, severityError, severityWarning:
+		default:
+			return nil, fmt.Errorf("\"%s\": the severity must be \"%s\" or "+
+				"\"%s\"", rule.Name(), severityError, severityWarning)
+		}
+
+		err := rule.Config(withDefaults(rule, settings))
+		if err != nil {
+			return nil, fmt.Errorf("\"%s\": %s", rule.Name(), err)
+		}
+		enabled = append(enabled, rule)
 	}
+	return enabled, nil
+}
 
-	return
+// exitOnConfError prints an error returned by configure and exits. Checking
+// can't continue with a misconfigured rule since its settings would otherwise
+// be silently ignored.
+func exitOnConfError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't configure rule %s.\n", err)
+		os.Exit(1)
+	}
 }
 
-// cleanMsg removes any commented-out or snipped content from a commit message.
-func cleanMsg(msg string) string {
-	remComments := bytes.Buffer{}
-	split := strings.SplitAfter(msg, "\n")
-	for _, line := range split {
-		trim := strings.TrimSpace(line)
-		if strings.HasPrefix(trim, string(commentChar)+" "+snipLine) {
-			break
+// warningRules returns the rules whose "severity" setting is "warning" in conf.
+// Violations of these rules are reported but don't cause the check to fail.
+func warningRules(conf map[string]interface{}) map[rules.Interface]bool {
+	warnings := map[rules.Interface]bool{}
+	for _, rule := range rules.All {
+		settings, _ := conf[rule.Name()].(map[string]interface{})
+		if settings[severitySetting] == severityWarning {
+			warnings[rule] = true
+		}
+	}
+	return warnings
+}
+
+// withDefaults returns a copy of a rule's settings where every documented
+// setting that's missing is set to nil, which resets it to its default.
+func withDefaults(rule rules.Interface,
+	settings map[string]interface{}) map[string]interface{} {
+	conf := make(map[string]interface{}, len(settings))
+	if doc, ok := rule.(rules.Documented); ok {
+		for _, s := range doc.Doc().Settings {
+			conf[s.Name] = nil
 		}
-		if strings.HasPrefix(trim, string(commentChar)) {
+	}
+	for name, setting := range settings {
+		conf[name] = setting
+	}
+	return conf
+}
+
+// cleanMsg cleans up a commit message the same way git will before storing it.
+// The "verbatim" cleanup mode leaves the message untouched. Otherwise, anything
+// after the snip line, trailing whitespace and extra blank lines are removed
+// as well as any commented-out lines when the mode strips comments.
+func cleanMsg(msg string, c cleanup) string {
+	if c.mode == "verbatim" {
+		return msg
+	}
+
+	msg = msg[:c.cutIndex(msg)]
+
+	buf := bytes.Buffer{}
+	empty := 0
+	for _, line := range strings.Split(msg, "\n") {
+		if c.stripComments() && strings.HasPrefix(line, c.commentChar) {
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			empty++
+			continue
+		}
+
+		if empty > 0 && buf.Len() > 0 {
+			buf.WriteRune('\n')
+		}
+		empty = 0
+		buf.WriteString(line)
+		buf.WriteRune('\n')
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// normalizeNewlines replaces every CRLF line ending in a message with LF. The
+// positions of the replaced line endings within the normalized message are also
+// returned so that positions can be mapped back to the original message.
+func normalizeNewlines(msg string) (normalized string, crlfs []int) {
+	if !strings.Contains(msg, "\r\n") {
+		return msg, nil
+	}
+
+	buf := bytes.Buffer{}
+	for i := 0; i < len(msg); i++ {
+		if msg[i] == '\r' && i+1 < len(msg) && msg[i+1] == '\n' {
+			crlfs = append(crlfs, buf.Len())
 			continue
 		}
+		buf.WriteByte(msg[i])
+	}
+	return buf.String(), crlfs
+}
 
-		remComments.WriteString(line)
+// originalPos maps a position in a normalized message back to its position in
+// the original message. A position that points to a normalized line ending will
+// point to the carriage return in the original message.
+func originalPos(pos int, crlfs []int) int {
+	orig := pos
+	for _, crlf := range crlfs {
+		if crlf >= pos {
+			break
+		}
+		orig++
 	}
-	return strings.TrimSpace(remComments.String())
+	return orig
 }
 
 // parseMsg parses a cleaned message by breaking it up into a subject and a
@@ -116,6 +359,17 @@ func parseMsg(cleanMsg string) (subject string, body string) {
 	return
 }
 
+// useColor returns true if output should be colored. Colors are only used when
+// stdout is a terminal and the NO_COLOR environment variable isn't set.
+func useColor() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	stat, err := os.Stdout.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
 func readConf() (conf map[string]interface{}) {
 	r, err := os.Open(confName)
 	if err != nil {
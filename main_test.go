@@ -2,11 +2,58 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/gcurtis/commitfmt/rules"
 )
 
+// defaultCleanup is git's cleanup behavior when it hasn't been configured.
+var defaultCleanup = cleanup{mode: "default", commentChar: defaultCommentChar}
+
+// inTempRepo creates an empty git repo in a temporary directory and changes
+// into it. The returned function changes back and removes the repo.
+func inTempRepo(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "commitfmt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	runGit(t, "init", "-q")
+	runGit(t, "config", "user.name", "commitfmt")
+	runGit(t, "config", "user.email", "commitfmt@example.com")
+	return func() {
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+	}
+}
+
+// runGit runs a git command and fails the test if it doesn't succeed.
+func runGit(t *testing.T, args ...string) string {
+	out, err := git(args...)
+	if err != nil {
+		t.Fatalf("git %s failed: %s", strings.Join(args, " "), err)
+	}
+	return out
+}
+
+// writeFile writes a file and fails the test if it can't.
+func writeFile(t *testing.T, path string, content string) {
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func reportHasViolation(rep *report, r rules.Interface) bool {
 	for _, v := range rep.violations {
 		if v.Rule == r {
@@ -19,7 +66,7 @@ func reportHasViolation(rep *report, r rules.Interface) bool {
 
 func TestEmptyMessage(t *testing.T) {
 	msg := ""
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.NoEmpty) {
 		t.Error("Expected violation:", ruleString(rules.NoEmpty))
@@ -28,7 +75,7 @@ func TestEmptyMessage(t *testing.T) {
 
 func TestWhitespaceMessage(t *testing.T) {
 	msg := " "
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.NoEmpty) {
 		t.Error("Expected violation:", ruleString(rules.NoEmpty))
@@ -37,7 +84,7 @@ func TestWhitespaceMessage(t *testing.T) {
 
 func TestValidSubject(t *testing.T) {
 	msg := "Subject"
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if rep.violations != nil {
 		t.Error("Unexpected violations:", rep.string())
@@ -46,7 +93,7 @@ func TestValidSubject(t *testing.T) {
 
 func TestValidSubjectWithBody(t *testing.T) {
 	msg := "Subject\n\nBody."
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if rep.violations != nil {
 		t.Error("Unexpected violations:", rep.string())
@@ -55,7 +102,7 @@ func TestValidSubjectWithBody(t *testing.T) {
 
 func TestMultilineSubject(t *testing.T) {
 	msg := "Subject1\nSubject2"
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.SubjOneLine) {
 		t.Error("Expected violations:", ruleString(rules.SubjOneLine))
@@ -64,16 +111,58 @@ func TestMultilineSubject(t *testing.T) {
 
 func TestSubjectThatIsTooLong(t *testing.T) {
 	msg := "This subject line goes over 50 characters=========="
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
+
+	if !reportHasViolation(rep, rules.SubjLen) {
+		t.Error("Expected violations:", ruleString(rules.SubjLen))
+	}
+}
+
+func TestSubjectWithAccentsThatIsNotTooLong(t *testing.T) {
+	msg := "Éviter les accès concurrents à la base de données"
+	rep, _ := runRules(msg, nil)
+
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestSubjectWithWideCharactersThatIsTooLong(t *testing.T) {
+	msg := "修复在并发访问数据库时出现的死锁问题并添加相关的回归测试用例"
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.SubjLen) {
 		t.Error("Expected violations:", ruleString(rules.SubjLen))
 	}
 }
 
+func TestSubjectLengthInRunes(t *testing.T) {
+	msg := "修复在并发访问数据库时出现的死锁问题并添加相关的回归测试用例"
+	conf := map[string]interface{}{
+		"subj-len": map[string]interface{}{
+			"unit": "runes",
+		},
+	}
+	defer func() { rules.SubjLen.Config(rules.SubjLen.DefaultConf) }()
+	rep, _ := runRules(msg, conf)
+
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestSubjectWithLowercaseAccent(t *testing.T) {
+	msg := "éliminer les accès concurrents"
+	rep, _ := runRules(msg, nil)
+
+	if !reportHasViolation(rep, rules.SubjSentenceCase) {
+		t.Error("Expected violations:", ruleString(rules.SubjSentenceCase))
+	}
+}
+
 func TestSubjectWithTitleCase(t *testing.T) {
 	msg := "This Subject Is Incorrectly Title Cased"
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.SubjSentenceCase) {
 		t.Error("Expected violations:", ruleString(rules.SubjSentenceCase))
@@ -82,16 +171,34 @@ func TestSubjectWithTitleCase(t *testing.T) {
 
 func TestSubjectWithExtraCapitalizedWords(t *testing.T) {
 	msg := "This subject is Incorrectly cased"
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.SubjSentenceCase) {
 		t.Error("Expected violations:", ruleString(rules.SubjSentenceCase))
 	}
 }
 
+func TestSentenceCaseMessages(t *testing.T) {
+	msg := "this subject is Incorrectly cased"
+	rep, _ := runRules(msg, nil)
+
+	expected := []string{
+		`The word "this" should be capitalized.`,
+		`The word "Incorrectly" should be lowercase.`,
+	}
+	if len(rep.violations) != len(expected) {
+		t.Fatal("Expected two violations:", rep.string())
+	}
+	for i, v := range rep.violations {
+		if v.Msg != expected[i] {
+			t.Errorf("Expected message %q but got %q", expected[i], v.Msg)
+		}
+	}
+}
+
 func TestSubjectWithAcronym(t *testing.T) {
 	msg := "Subject with the acronym ID"
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if rep.violations != nil {
 		t.Error("Unexpected violations:", rep.string())
@@ -100,7 +207,7 @@ func TestSubjectWithAcronym(t *testing.T) {
 
 func TestSubjectWithCamelCase(t *testing.T) {
 	msg := "Subject with the class name MyClass in it"
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if rep.violations != nil {
 		t.Error("Unexpected violations:", rep.string())
@@ -109,7 +216,7 @@ func TestSubjectWithCamelCase(t *testing.T) {
 
 func TestSubjectWithPeriod(t *testing.T) {
 	msg := "This subject ends with a period."
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.SubjNoPeriod) {
 		t.Error("Expected violations:", ruleString(rules.SubjNoPeriod))
@@ -118,7 +225,7 @@ func TestSubjectWithPeriod(t *testing.T) {
 
 func TestSubjectWithEllipsis(t *testing.T) {
 	msg := "This subject ends with ellipsis..."
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if rep.violations != nil {
 		t.Error("Unexpected violations:", rep.string())
@@ -132,7 +239,7 @@ Paragraph1.
 
 Paragraph2 with
 multiple lines.`
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if rep.violations != nil {
 		t.Error("Unexpected violations:", rep.string())
@@ -141,7 +248,7 @@ multiple lines.`
 
 func TestSubjectWithMultipleSpaces(t *testing.T) {
 	msg := "Subject  with multiple spaces"
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.Whitespace) {
 		t.Error("Expected violations:", ruleString(rules.Whitespace))
@@ -152,7 +259,7 @@ func TestBodyWithMultipleSpaces(t *testing.T) {
 	msg := `Subject
 
 Body with  multiple spaces.`
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.Whitespace) {
 		t.Error("Expected violations:", ruleString(rules.Whitespace))
@@ -168,7 +275,7 @@ Paragraph 2.
 
 
 Paragraph 3.`
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.Whitespace) {
 		t.Error("Expected violations:", ruleString(rules.Whitespace))
@@ -177,7 +284,7 @@ Paragraph 3.`
 
 func TestSubjectWithTrailingSpace(t *testing.T) {
 	msg := "Subject with trailing space \n\nBody."
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.Whitespace) {
 		t.Error("Expected violations:", ruleString(rules.Whitespace))
@@ -186,7 +293,7 @@ func TestSubjectWithTrailingSpace(t *testing.T) {
 
 func TestBodyWithTrailingSpace(t *testing.T) {
 	msg := "Subject\n\nParagraph1. \n\nParagraph2."
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.Whitespace) {
 		t.Error("Expected violations:", ruleString(rules.Whitespace))
@@ -197,7 +304,7 @@ func TestBodyWithLineThatIsTooLong(t *testing.T) {
 	msg := `Subject
 
 Paragraph that is longer that 72 characters=============================.`
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.BodyLen) {
 		t.Error("Expected violations:", ruleString(rules.BodyLen))
@@ -208,7 +315,7 @@ func TestBodyThatDoesNotEndWithPunctuation(t *testing.T) {
 	msg := `Subject
 
 Paragraph that doesn't end with punctuation`
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if !reportHasViolation(rep, rules.BodyPunc) {
 		t.Error("Expected violations:", ruleString(rules.BodyPunc))
@@ -219,7 +326,7 @@ func TestBodyListThatDoesNotEndWithPunctuation(t *testing.T) {
 	msg := `Subject
 
 * This is a list item`
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if rep.violations != nil {
 		t.Error("Unexpected violations:", rep.string())
@@ -248,7 +355,7 @@ Further paragraphs come after blank lines.
   single space, with blank lines in between, but conventions vary here
 
 - Use a hanging indent`
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 
 	if rep.violations != nil {
 		t.Error("Unexpected violations:", rep.string())
@@ -258,7 +365,7 @@ Further paragraphs come after blank lines.
 func TestDisabledRule(t *testing.T) {
 	msg := "Subject that ends with a period."
 	conf := map[string]interface{}{"subj-no-period": false}
-	rep := runRules(msg, conf)
+	rep, _ := runRules(msg, conf)
 
 	if rep.violations != nil {
 		t.Error("Unexpected violations:", rep.string())
@@ -274,7 +381,7 @@ func TestSubjectMatchesRegex(t *testing.T) {
 		},
 	}
 	defer func() { rules.SubjRegex.Config(rules.SubjRegex.DefaultConf) }()
-	rep := runRules(msg, conf)
+	rep, _ := runRules(msg, conf)
 
 	if rep.violations != nil {
 		t.Error("Unexpected violations:", rep.string())
@@ -289,49 +396,1117 @@ func TestSubjectDoesNotMatchRegex(t *testing.T) {
 		},
 	}
 	defer func() { rules.SubjRegex.Config(rules.SubjRegex.DefaultConf) }()
-	rep := runRules(msg, conf)
+	rep, _ := runRules(msg, conf)
 
 	if !reportHasViolation(rep, rules.SubjRegex) {
 		t.Error("Expected violations:", ruleString(rules.SubjRegex))
 	}
 }
 
-func Example1() {
+func TestIssueRefDisabledByDefault(t *testing.T) {
+	msg := "Subject without a reference"
+	rep, _ := runRules(msg, nil)
+
+	if reportHasViolation(rep, rules.IssueRef) {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestMissingIssueRef(t *testing.T) {
+	msg := "Subject without a reference\n\nBody without one either."
+	conf := map[string]interface{}{"issue-ref": true}
+	rep, _ := runRules(msg, conf)
+
+	if !reportHasViolation(rep, rules.IssueRef) {
+		t.Error("Expected violations:", ruleString(rules.IssueRef))
+	}
+}
+
+func TestIssueRefInBody(t *testing.T) {
+	msg := "Subject\n\nThis fixes the bug described in #123."
+	conf := map[string]interface{}{"issue-ref": true}
+	rep, _ := runRules(msg, conf)
+
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestIssueRefWithInvalidProject(t *testing.T) {
+	msg := "Subject\n\nThis fixes ABC-123."
+	conf := map[string]interface{}{
+		"issue-ref": map[string]interface{}{
+			"projects": []interface{}{"PAY"},
+		},
+	}
+	defer func() { rules.IssueRef.Config(rules.IssueRef.DefaultConf) }()
+	rep, _ := runRules(msg, conf)
+
+	if !reportHasViolation(rep, rules.IssueRef) {
+		t.Error("Expected violations:", ruleString(rules.IssueRef))
+	}
+}
+
+func TestIssueRefInTrailer(t *testing.T) {
+	conf := map[string]interface{}{
+		"body-punc": false,
+		"issue-ref": map[string]interface{}{
+			"location": "trailers",
+		},
+	}
+	defer func() { rules.IssueRef.Config(rules.IssueRef.DefaultConf) }()
+
+	msg := "Subject\n\nThis fixes PAY-481.\n\nSigned-off-by: Someone"
+	rep, _ := runRules(msg, conf)
+	if !reportHasViolation(rep, rules.IssueRef) {
+		t.Error("Expected violations:", ruleString(rules.IssueRef))
+	}
+
+	msg = "Subject\n\nThis fixes a bug.\n\nRefs: PAY-481"
+	rep, _ = runRules(msg, conf)
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestIssueRefFromBranch(t *testing.T) {
+	conf := map[string]interface{}{
+		"issue-ref": map[string]interface{}{
+			"branch": true,
+		},
+	}
+	rules.Env.Branch = "feature/PAY-481-refunds"
+	defer func() {
+		rules.Env.Branch = ""
+		rules.IssueRef.Config(rules.IssueRef.DefaultConf)
+	}()
+
+	msg := "Subject\n\nThis adds refunds for PAY-418."
+	rep, _ := runRules(msg, conf)
+	if !reportHasViolation(rep, rules.IssueRef) {
+		t.Error("Expected violations:", ruleString(rules.IssueRef))
+	}
+
+	msg = "Subject\n\nThis adds refunds for PAY-481."
+	rep, _ = runRules(msg, conf)
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestConfigureResetsSettings(t *testing.T) {
+	defer rules.SubjRegex.Config(rules.SubjRegex.DefaultConf)
+	conf := map[string]interface{}{
+		"subj-regex": map[string]interface{}{"pattern": "^[A-Z]+-[0-9]+ "},
+	}
+	if rep, _ := runRules("Subject", conf); rep.violations == nil {
+		t.Error("Expected violations:", ruleString(rules.SubjRegex))
+	}
+
+	if rep, _ := runRules("Subject", nil); rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestConfigureError(t *testing.T) {
+	defer rules.IssueRef.Config(rules.IssueRef.DefaultConf)
+	defer rules.Regex.Config(rules.Regex.DefaultConf)
+	confs := []map[string]interface{}{
+		{"issue-ref": map[string]interface{}{"location": "subjct"}},
+		{"subj-len": map[string]interface{}{"severity": "warn"}},
+		{"regex": map[string]interface{}{"patterns": map[string]interface{}{
+			"ticket": map[string]interface{}{"must-match": "[A-Z]+-[0-9]+"},
+			"broken": map[string]interface{}{"must-match": "("},
+		}}},
+	}
+	for _, conf := range confs {
+		if _, err := runRules("Subject", conf); err == nil {
+			t.Error("Expected an error for conf:", conf)
+		}
+	}
+}
+
+func TestPushedRefs(t *testing.T) {
+	if !isZeroSHA("0000000000000000000000000000000000000000") {
+		t.Error("Expected a zero SHA")
+	}
+	if isZeroSHA("8e2d8440000000000000000000000000000000000") {
+		t.Error("Unexpected zero SHA")
+	}
+	if branch := refBranch("refs/heads/release/2.0"); branch != "release/2.0" {
+		t.Errorf(`Expected branch "release/2.0" but got "%s"`, branch)
+	}
+	if branch := refBranch("refs/tags/v2.0"); branch != "" {
+		t.Errorf(`Expected no branch but got "%s"`, branch)
+	}
+}
+
+func TestCheckRef(t *testing.T) {
+	defer inTempRepo(t)()
+	commit := func(msg string) string {
+		runGit(t, "commit", "-q", "--allow-empty", "-m", msg)
+		return runGit(t, "rev-parse", "HEAD")
+	}
+	zero := strings.Repeat("0", 40)
+	ref := "refs/heads/feature"
+
+	// HEAD is kept on the trunk when checking since a server's HEAD points to
+	// its default branch.
+	commit("Add the first feature")
+	trunk := currentBranch()
+	runGit(t, "checkout", "-q", "-b", "feature")
+	created := commit("add the second feature.")
+	runGit(t, "checkout", "-q", trunk)
+	commits, err := pushedCommits(ref, zero, created)
+	if err != nil || !reflect.DeepEqual(commits, []string{created}) {
+		t.Errorf("Expected only the new branch's commit but got %v (%v)",
+			commits, err)
+	}
+	if failed := checkRef(nil, ref, zero, created); failed != 1 {
+		t.Errorf("Expected 1 failed commit on the new branch but got %d",
+			failed)
+	}
+
+	commit("fix the trunk.")
+	runGit(t, "checkout", "-q", "feature")
+	runGit(t, "merge", "-q", "--no-edit", trunk)
+	merged := runGit(t, "rev-parse", "HEAD")
+	updated := commit("Add the third feature")
+	runGit(t, "checkout", "-q", trunk)
+	commits, err = pushedCommits(ref, created, updated)
+	if err != nil || !reflect.DeepEqual(commits, []string{merged, updated}) {
+		t.Errorf("Expected only the merge and the new commit but got %v (%v)",
+			commits, err)
+	}
+	if failed := checkRef(nil, ref, created, updated); failed != 0 {
+		t.Errorf("Expected the trunk's commits to be skipped but %d failed",
+			failed)
+	}
+
+	if failed := checkRef(nil, ref, updated, zero); failed != 0 {
+		t.Errorf("Expected deleting a branch to pass but %d commits failed",
+			failed)
+	}
+}
+
+func TestCheckRefWithDisableTrailer(t *testing.T) {
+	defer inTempRepo(t)()
+	runGit(t, "commit", "-q", "--allow-empty", "-m", "Subject that ends with "+
+		"a period.\n\nThe period is part of a quote.\n\nCommitfmt-Disable: "+
+		"subj-no-period -- The subject is a quote.")
+	sha := runGit(t, "rev-parse", "HEAD")
+
+	ref := "refs/heads/" + currentBranch()
+	// Move HEAD off of the branch so that the commit counts as new.
+	runGit(t, "checkout", "-q", "--orphan", "other")
+	zero := strings.Repeat("0", 40)
+	if failed := checkRef(nil, ref, zero, sha); failed != 0 {
+		t.Errorf("Expected the trailer to disable the rule but %d commits "+
+			"failed", failed)
+	}
+}
+
+func TestBranchOverrides(t *testing.T) {
+	defer func() {
+		rules.Env.Branch = ""
+		rules.SubjRegex.Config(rules.SubjRegex.DefaultConf)
+	}()
+	conf := map[string]interface{}{
+		"branches": map[string]interface{}{
+			"release/*": map[string]interface{}{
+				"subj-regex": map[string]interface{}{"pattern": "^[A-Z]+-[0-9]+ "},
+			},
+			"release/legacy": map[string]interface{}{"subj-regex": false},
+			"wip/*":          false,
+		},
+	}
+	msg := "Subject with a period."
+
+	rules.Env.Branch = "release/2.0"
+	rep, _ := runRules(msg, conf)
+	if !reportHasViolation(rep, rules.SubjRegex) {
+		t.Error("Expected violations:", ruleString(rules.SubjRegex))
+	}
+
+	rules.Env.Branch = "release/legacy"
+	rep, _ = runRules(msg, conf)
+	if reportHasViolation(rep, rules.SubjRegex) {
+		t.Error("Unexpected violations:", rep.string())
+	}
+
+	rules.Env.Branch = "wip/refunds"
+	if rep, _ = runRules(msg, conf); rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+
+	rules.Env.Branch = "feature/release/2.0"
+	rep, _ = runRules(msg, conf)
+	if reportHasViolation(rep, rules.SubjRegex) {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		matched bool
+	}{
+		{"api/**", "api/server.go", true},
+		{"api/**", "api/v2/server.go", true},
+		{"api/*", "api/v2/server.go", false},
+		{"**/*.md", "README.md", true},
+		{"**/*.md", "docs/rules.md", true},
+		{"api/**/*.go", "api/server.go", true},
+		{"api/**/*.go", "web/api/server.go", false},
+		{"public/*.html", "public/index.html", true},
+	}
+	for _, test := range tests {
+		if rules.MatchGlob(test.pattern, test.name) != test.matched {
+			t.Errorf("Expected MatchGlob(%q, %q) to be %t", test.pattern,
+				test.name, test.matched)
+		}
+	}
+}
+
+func TestPathOverrides(t *testing.T) {
+	defer func() {
+		rules.Env.Files = nil
+		rules.SubjRegex.Config(rules.SubjRegex.DefaultConf)
+	}()
+	conf := map[string]interface{}{
+		"paths": map[string]interface{}{
+			"api/**": map[string]interface{}{
+				"subj-regex": map[string]interface{}{"pattern": `^\w+\(api\): `},
+			},
+		},
+	}
+	msg := "Add an endpoint for refunds"
+
+	rules.Env.Files = []string{"README.md", "api/refunds.go"}
+	rep, _ := runRules(msg, conf)
+	if !reportHasViolation(rep, rules.SubjRegex) {
+		t.Error("Expected violations:", ruleString(rules.SubjRegex))
+	}
+
+	rules.Env.Files = []string{"web/refunds.js"}
+	if rep, _ = runRules(msg, conf); rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestScopeMatchesPaths(t *testing.T) {
+	defer func() { rules.Env.Files = nil }()
+	conf := map[string]interface{}{
+		"subj-sentence-case": false,
+		"scope-matches-paths": map[string]interface{}{
+			"scopes": map[string]interface{}{
+				"core": "core/**",
+				"ui":   []interface{}{"ui/**"},
+			},
+		},
+	}
+	rules.Env.Files = []string{"ui/refund.js", "core/refund.go", "core/api.go"}
+
+	rep, _ := runRules("feat(core, ui): Add refunds", conf)
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+
+	rep, _ = runRules("feat(ui): Add refunds", conf)
+	if !reportHasViolation(rep, rules.ScopeMatchesPaths) {
+		t.Fatal("Expected violations:", ruleString(rules.ScopeMatchesPaths))
+	}
+	v := rep.violations[0]
+	if v.Pos != 5 || v.End != 7 {
+		t.Errorf("Expected the violation to span the scope but got %d-%d",
+			v.Pos, v.End)
+	}
+	expected := `The scope "ui" doesn't cover core/refund.go and 1 other files.`
+	if v.Msg != expected {
+		t.Errorf("Expected message %q but got %q", expected, v.Msg)
+	}
+}
+
+func TestBodyRequired(t *testing.T) {
+	defer func() {
+		rules.Env.Files = nil
+		rules.Env.Lines = 0
+	}()
+	conf := map[string]interface{}{
+		"body-required": map[string]interface{}{
+			"lines": 500.0,
+			"files": 10.0,
+			"paths": []interface{}{"db/migrations/**"},
+		},
+	}
+	msg := "Add refunds"
+
+	rules.Env.Files = []string{"refunds.go"}
+	rules.Env.Lines = 120
+	if rep, _ := runRules(msg, conf); rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+
+	rules.Env.Lines = 2000
+	rep, _ := runRules(msg, conf)
+	if !reportHasViolation(rep, rules.BodyRequired) {
+		t.Error("Expected violations:", ruleString(rules.BodyRequired))
+	}
+	if rep, _ = runRules(msg+"\n\nRefunds are issued from the order page.",
+		conf); rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+
+	rules.Env.Lines = 10
+	rules.Env.Files = []string{"refunds.go", "db/migrations/004_refunds.sql"}
+	rep, _ = runRules(msg, conf)
+	if !reportHasViolation(rep, rules.BodyRequired) {
+		t.Error("Expected violations:", ruleString(rules.BodyRequired))
+	}
+}
+
+func TestBreakingChange(t *testing.T) {
+	defer rules.BreakingChange.Config(rules.BreakingChange.DefaultConf)
+	conf := map[string]interface{}{
+		"subj-sentence-case": false,
+		"body-punc":          false,
+		"breaking-change":    map[string]interface{}{"require-marker": true},
+	}
+
+	rep, _ := runRules("feat(api)!: Remove the v1 endpoints", conf)
+	if !reportHasViolation(rep, rules.BreakingChange) {
+		t.Fatal("Expected violations:", ruleString(rules.BreakingChange))
+	}
+	if pos := rep.violations[0].Pos; pos != 9 {
+		t.Errorf("Expected the violation to point to the \"!\" but got %d",
+			pos)
+	}
+
+	rep, _ = runRules("feat(api): Remove the v1 endpoints\n\nRefs: PAY-481\n"+
+		"BREAKING CHANGE: Clients must use v2.", conf)
+	if !reportHasViolation(rep, rules.BreakingChange) {
+		t.Fatal("Expected violations:", ruleString(rules.BreakingChange))
+	}
+	expected := "The message has a breaking change footer, so the type " +
+		"should be followed by a \"!\", e.g., \"feat(api)!:\"."
+	if msg := rep.violations[0].Msg; msg != expected {
+		t.Errorf("Expected message %q but got %q", expected, msg)
+	}
+
+	rep, _ = runRules("feat(api)!: Remove the v1 endpoints\n\nRefs: PAY-481\n"+
+		"BREAKING CHANGE: Clients must use v2.", conf)
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestBannedWords(t *testing.T) {
+	conf := map[string]interface{}{
+		"body-punc": false,
+		"banned-words": map[string]interface{}{
+			"words": []interface{}{
+				"stuff",
+				map[string]interface{}{
+					"word":           "TODO",
+					"case-sensitive": true,
+					"message":        "Finish the change before committing it.",
+				},
+			},
+			"patterns": []interface{}{
+				map[string]interface{}{
+					"pattern":   `falcon-\d+`,
+					"locations": []interface{}{"trailers"},
+				},
+			},
+		},
+	}
+	msg := "Fix Stuff in the todo list\n\nSee falcon-12 for the TODO.\n\n" +
+		"Refs: falcon-12"
+
+	rep, _ := runRules(msg, conf)
+	var spans [][2]int
+	for _, v := range rep.violations {
+		if v.Rule == rules.BannedWords {
+			spans = append(spans, [2]int{v.Pos, v.End})
+		}
+	}
+	expected := [][2]int{{4, 9}, {50, 54}, {63, 72}}
+	if !reflect.DeepEqual(spans, expected) {
+		t.Errorf("Expected violations at %v but got %v", expected, spans)
+	}
+}
+
+func TestSpelling(t *testing.T) {
+	conf := map[string]interface{}{
+		"spelling": map[string]interface{}{"words": []interface{}{"commitfmt"}},
+	}
+	msg := "Fix the parser so that commitfmt handles recieved messages\n\n" +
+		"The `parseMsg` function in main.go now returns early when it's " +
+		"given an empty message. Thanks to Gaston for reporting it in " +
+		"https://example.com/issues/12 and 8e2d844.\n\n" +
+		"Signed-off-by: Gaston Smyth <gaston@example.com>"
+
+	rep, _ := runRules(msg, conf)
+	var words []string
+	for _, v := range rep.violations {
+		if v.Rule == rules.Spelling {
+			words = append(words, msg[v.Pos:v.End])
+		}
+	}
+	if !reflect.DeepEqual(words, []string{"recieved"}) {
+		t.Errorf("Expected only \"recieved\" to be misspelled but got %q",
+			words)
+	}
+}
+
+func TestWarningSeverity(t *testing.T) {
+	conf := map[string]interface{}{
+		"subj-no-period": map[string]interface{}{"severity": "warning"},
+	}
+	rep, _ := runRules("Subject that ends with a period.", conf)
+	if !reportHasViolation(rep, rules.SubjNoPeriod) {
+		t.Fatal("Expected violations:", ruleString(rules.SubjNoPeriod))
+	}
+	if rep.failed() {
+		t.Error("Expected warnings not to fail the check")
+	}
+	if !strings.HasSuffix(rep.string(), "0 formatting errors and 1 warnings "+
+		"were found.") {
+		t.Error("Expected the warning to be counted:", rep.string())
+	}
+}
+
+func TestRegexPatterns(t *testing.T) {
+	conf := map[string]interface{}{
+		"body-punc": false,
+		"regex": map[string]interface{}{
+			"patterns": map[string]interface{}{
+				"signed-off": map[string]interface{}{
+					"must-match":  "^Signed-off-by: ",
+					"target":      "trailers",
+					"description": "Only sign-offs are allowed as trailers.",
+				},
+				"no-todo": map[string]interface{}{
+					"must-not-match": "TODO",
+					"target":         "paragraphs",
+				},
+			},
+		},
+	}
+	msg := "Add refunds\n\nRefunds can be issued.\nTODO: Partial refunds." +
+		"\n\nSigned-off-by: Jane Doe <jane@example.com>\nRefs: PAY-481"
+
+	rep, _ := runRules(msg, conf)
+	var msgs []string
+	for _, v := range rep.violations {
+		if v.Rule == rules.Regex {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", msg[v.Pos:v.End], v.Msg))
+		}
+	}
+	expected := []string{
+		"TODO: \"TODO\" matches the \"no-todo\" pattern, which isn't allowed.",
+		"Refs: PAY-481: Only sign-offs are allowed as trailers.",
+	}
+	if !reflect.DeepEqual(msgs, expected) {
+		t.Errorf("Expected violations:\n%q\nbut got:\n%q", expected, msgs)
+	}
+}
+
+func TestSubjectThatIsTooShort(t *testing.T) {
+	defer rules.SubjMin.Config(rules.SubjMin.DefaultConf)
+	if rep, _ := runRules("WIP", nil); reportHasViolation(rep, rules.SubjMin) {
+		t.Error("Expected subj-min to be disabled by default:", rep.string())
+	}
+
+	enabled := map[string]interface{}{"subj-min": true}
+	for _, msg := range []string{"x", "WIP", "Update."} {
+		if rep, _ := runRules(msg, enabled); !reportHasViolation(rep,
+			rules.SubjMin) {
+			t.Errorf("Expected violations for %q: %s", msg,
+				ruleString(rules.SubjMin))
+		}
+	}
+
+	conf := map[string]interface{}{
+		"subj-min": map[string]interface{}{
+			"min-words": 3.0,
+			"rejected":  []interface{}{"Bump version"},
+		},
+	}
+	for _, msg := range []string{"Add refunds", "Bump version"} {
+		if rep, _ := runRules(msg, conf); !reportHasViolation(rep, rules.SubjMin) {
+			t.Errorf("Expected violations for %q: %s", msg,
+				ruleString(rules.SubjMin))
+		}
+	}
+	rep, _ := runRules("Update the changelog", conf)
+	if reportHasViolation(rep, rules.SubjMin) {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestMergeMessage(t *testing.T) {
+	msg := "Merge branch 'feature/a-very-long-branch-name' into main"
+	rep, _ := runRules(msg, nil)
+
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestMergeMessageWithOverrides(t *testing.T) {
+	msg := "Merge branch 'feature/a-very-long-branch-name' into main"
+	conf := map[string]interface{}{
+		"kinds": map[string]interface{}{
+			"merge": map[string]interface{}{
+				"subj-sentence-case": false,
+			},
+		},
+	}
+	rep, _ := runRules(msg, conf)
+
+	if !reportHasViolation(rep, rules.SubjLen) {
+		t.Error("Expected violations:", ruleString(rules.SubjLen))
+	}
+	if reportHasViolation(rep, rules.SubjSentenceCase) {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestRevertMessage(t *testing.T) {
+	msg := `Revert "Subject that is almost at the length limit"
+
+This reverts commit 1234567890abcdef1234567890abcdef12345678.`
+	rep, _ := runRules(msg, nil)
+
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestFixupMessage(t *testing.T) {
+	msg := "fixup! Subject"
+	rep, _ := runRules(msg, nil)
+
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestFixupMessageWithViolation(t *testing.T) {
+	msg := "fixup! fixup! Subject that ends with a period."
+	rep, _ := runRules(msg, nil)
+
+	if !reportHasViolation(rep, rules.SubjNoPeriod) {
+		t.Fatal("Expected violations:", ruleString(rules.SubjNoPeriod))
+	}
+	if rep.violations[0].Pos != len(msg)-1 {
+		t.Errorf("Expected violation at %d but got %d", len(msg)-1,
+			rep.violations[0].Pos)
+	}
+}
+
+func TestCleanMsgStrip(t *testing.T) {
+	msg := "\nSubject  \n\n\n# A comment\nBody.\t\n\n# Please enter the " +
+		"commit message.\n# " + snipLine + "\ndiff --git a/file b/file\n"
+	cleaned := cleanMsg(msg, defaultCleanup)
+
+	if expected := "Subject\n\nBody."; cleaned != expected {
+		t.Errorf("Expected cleaned message %q but got %q", expected, cleaned)
+	}
+}
+
+func TestCleanMsgWhitespace(t *testing.T) {
+	msg := "Subject\n\n#123 is fixed.  \n"
+	cleaned := cleanMsg(msg, cleanup{mode: "whitespace", commentChar: "#"})
+
+	if expected := "Subject\n\n#123 is fixed."; cleaned != expected {
+		t.Errorf("Expected cleaned message %q but got %q", expected, cleaned)
+	}
+}
+
+func TestCleanMsgVerbatim(t *testing.T) {
+	msg := "Subject \n\n# Body.\n"
+	cleaned := cleanMsg(msg, cleanup{mode: "verbatim", commentChar: "#"})
+
+	if cleaned != msg {
+		t.Errorf("Expected cleaned message %q but got %q", msg, cleaned)
+	}
+}
+
+func TestCleanMsgVerbatimKeepsSnipLine(t *testing.T) {
+	msg := "Subject\n\n# " + snipLine + "\nDiff.\n"
+	cleaned := cleanMsg(msg, cleanup{mode: "verbatim", commentChar: "#"})
+
+	if cleaned != msg {
+		t.Errorf("Expected cleaned message %q but got %q", msg, cleaned)
+	}
+}
+
+func TestVerbatimPositions(t *testing.T) {
+	msg := "\n\nSubject  with two spaces"
+	cleaned := cleanMsg(msg, cleanup{mode: "verbatim", commentChar: "#"})
+	rep, _ := runRules(cleaned, nil)
+
+	for _, v := range rep.violations {
+		if v.Rule == rules.Whitespace && v.Pos != 10 {
+			t.Errorf("Expected the violation at 10 but got %d: %s", v.Pos,
+				rep.string())
+		}
+	}
+	if !reportHasViolation(rep, rules.Whitespace) {
+		t.Error("Expected violations:", ruleString(rules.Whitespace))
+	}
+}
+
+func TestCleanMsgCommentChar(t *testing.T) {
+	msg := "Subject\n\n#123 is fixed.\n; A comment\n"
+	cleaned := cleanMsg(msg, cleanup{mode: "strip", commentChar: ";"})
+
+	if expected := "Subject\n\n#123 is fixed."; cleaned != expected {
+		t.Errorf("Expected cleaned message %q but got %q", expected, cleaned)
+	}
+}
+
+func TestDetectCommentChar(t *testing.T) {
+	msg := "Subject\n\n#123 is fixed.\n; Please enter the commit message.\n"
+
+	if char := detectCommentChar(msg); char != ";" {
+		t.Errorf("Expected comment char %q but got %q", ";", char)
+	}
+}
+
+func TestWhitespaceSpans(t *testing.T) {
+	msg := "Subject   with extra spaces  \n\nBody."
+	rep, _ := runRules(msg, nil)
+
+	if len(rep.violations) != 2 {
+		t.Fatal("Expected two violations:", rep.string())
+	}
+	extra := strings.Index(msg, "   ") + 1
+	if v := rep.violations[0]; v.Pos != extra || v.End != extra+2 {
+		t.Errorf("Expected extra spaces at [%d, %d) but got [%d, %d)", extra,
+			extra+2, v.Pos, v.End)
+	}
+	trailing := strings.Index(msg, "  \n")
+	if v := rep.violations[1]; v.Pos != trailing || v.End != trailing+2 {
+		t.Errorf("Expected trailing spaces at [%d, %d) but got [%d, %d)",
+			trailing, trailing+2, v.Pos, v.End)
+	}
+}
+
+func TestMessageWithCRLF(t *testing.T) {
+	msg := "Subject\r\n\r\nBody with  two spaces.\r\n"
+	conf := map[string]interface{}{"line-endings": false}
+	rep, _ := runRules(msg, conf)
+
+	if len(rep.violations) != 1 || rep.violations[0].Rule != rules.Whitespace {
+		t.Fatal("Expected one violation:", ruleString(rules.Whitespace))
+	}
+	if pos := strings.Index(msg, "  ") + 1; rep.violations[0].Pos != pos {
+		t.Errorf("Expected violation at %d but got %d", pos,
+			rep.violations[0].Pos)
+	}
+}
+
+func TestLineEndings(t *testing.T) {
+	msg := "Subject\r\n\r\nBody.\r\n"
+	rep, _ := runRules(msg, nil)
+
+	if !reportHasViolation(rep, rules.LineEndings) {
+		t.Fatal("Expected violations:", ruleString(rules.LineEndings))
+	}
+	if pos := strings.Index(msg, "\r"); rep.violations[0].Pos != pos {
+		t.Errorf("Expected violation at %d but got %d", pos,
+			rep.violations[0].Pos)
+	}
+}
+
+func TestLineEndingsFix(t *testing.T) {
+	msg := "Subject\r\n\r\nBody.\r\n"
+	conf := map[string]interface{}{
+		"line-endings": map[string]interface{}{
+			"fix": true,
+		},
+	}
+	defer func() { rules.LineEndings.Config(rules.LineEndings.DefaultConf) }()
+	rep, _ := runRules(msg, conf)
+
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+}
+
+func TestCheckFixesLineEndings(t *testing.T) {
+	defer inTempRepo(t)()
+	defer func() { rules.LineEndings.Config(rules.LineEndings.DefaultConf) }()
+	runGit(t, "config", "commit.cleanup", "verbatim")
+	writeFile(t, confName, `{"line-endings": {"fix": true}}`)
+	writeFile(t, "COMMIT_EDITMSG", "Subject\r\n\r\nBody.\r\n")
+
+	check("COMMIT_EDITMSG")
+	b, err := ioutil.ReadFile("COMMIT_EDITMSG")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := string(b); msg != "Subject\n\nBody.\n" {
+		t.Errorf("Expected the line endings to be fixed but got %q", msg)
+	}
+}
+
+func TestDisableDirective(t *testing.T) {
+	msg := `Subject
+
+Paragraph that is longer that 72 characters=============================.
+# commitfmt-disable body-len -- The line is a URL.`
+	cleaned := cleanMsg(msg, defaultCleanup)
+	rep, _ := runRules(cleaned, nil)
+	rep.suppress(parseDirectives(msg, defaultCleanup), false)
+
+	if rep.violations != nil {
+		t.Error("Unexpected violations:", rep.string())
+	}
+	if len(rep.suppressed) != 1 || rep.suppressed[0].rule != "body-len" ||
+		rep.suppressed[0].reason != "The line is a URL." {
+		t.Errorf("Expected body-len to be suppressed but got %v",
+			rep.suppressed)
+	}
+}
+
+func TestDisableNextLineDirective(t *testing.T) {
+	msg := `Subject
+
+# commitfmt-disable-next-line body-len
+Paragraph that is longer that 72 characters=============================.
+Another paragraph that is longer that 72 characters=====================.`
+	cleaned := cleanMsg(msg, defaultCleanup)
+	rep, _ := runRules(cleaned, nil)
+	rep.suppress(parseDirectives(msg, defaultCleanup), false)
+
+	if len(rep.violations) != 1 || rep.violations[0].Rule != rules.BodyLen {
+		t.Fatal("Expected one violation:", ruleString(rules.BodyLen))
+	}
+	if line, _, _ := rep.lineChar(rep.violations[0].Pos); line !=
+		strings.Index(cleaned, "Another") {
+		t.Error("Expected violation on the last line:", rep.string())
+	}
+}
+
+func TestDisableNextLineDirectiveWithIdenticalLines(t *testing.T) {
+	long := "Paragraph that is longer that 72 characters=====================" +
+		"========."
+	msg := "Subject\n\n# commitfmt-disable-next-line body-len\n" + long +
+		"\n\n# A comment between the paragraphs.\n\n" + long
+	cleaned := cleanMsg(msg, defaultCleanup)
+	rep, _ := runRules(cleaned, nil)
+	rep.suppress(parseDirectives(msg, defaultCleanup), false)
+
+	if len(rep.violations) != 1 || rep.violations[0].Rule != rules.BodyLen {
+		t.Fatal("Expected one violation:", ruleString(rules.BodyLen))
+	}
+	if line, _, _ := rep.lineChar(rep.violations[0].Pos); line !=
+		strings.LastIndex(cleaned, long) {
+		t.Error("Expected violation on the last line:", rep.string())
+	}
+}
+
+func TestDisableDirectiveWithUnknownRule(t *testing.T) {
+	msg := `Subject that ends with a period.
+
+# commitfmt-disable subj-no-periodd`
+	rep, _ := runRules(cleanMsg(msg, defaultCleanup), nil)
+	rep.suppress(parseDirectives(msg, defaultCleanup), false)
+
+	if !reportHasViolation(rep, rules.SubjNoPeriod) {
+		t.Error("Expected violations:", ruleString(rules.SubjNoPeriod))
+	}
+	if len(rep.suppressed) != 0 {
+		t.Errorf("Expected no suppressed rules but got %v", rep.suppressed)
+	}
+	if len(rep.notes) != 1 || !strings.Contains(rep.notes[0],
+		`there isn't a rule named "subj-no-periodd"`) {
+		t.Errorf("Expected a note about the unknown rule but got %v",
+			rep.notes)
+	}
+}
+
+func TestDisableDirectiveWithoutRequiredReason(t *testing.T) {
+	msg := `Subject that ends with a period.
+# commitfmt-disable subj-no-period`
+	cleaned := cleanMsg(msg, defaultCleanup)
+	rep, _ := runRules(cleaned, nil)
+	rep.suppress(parseDirectives(msg, defaultCleanup), true)
+
+	if !reportHasViolation(rep, rules.SubjNoPeriod) {
+		t.Error("Expected violations:", ruleString(rules.SubjNoPeriod))
+	}
+	if len(rep.notes) != 1 {
+		t.Error("Expected a note about the ignored directive")
+	}
+}
+
+func TestPrepareInsertsTemplateAndGuidance(t *testing.T) {
+	msg := "\n# Please enter the commit message for your changes.\n"
+	prepConf := prepareConf{template: "feat: {ticket} ", guidance: true}
+	rules.Env.Branch = "feature/PAY-481-refunds"
+	defer func() { rules.Env.Branch = "" }()
+	prepared := prepareMsg(msg, defaultCleanup, prepConf, []rules.Interface{rules.SubjLen})
+
+	expected := "feat: PAY-481 \n" +
+		"# Please enter the commit message for your changes.\n" +
+		"# commitfmt will check this message against the following rules:\n" +
+		"#   " + ruleString(rules.SubjLen) + "\n"
+	if prepared != expected {
+		t.Errorf("Expected prepared message:\n%s\nbut got:\n%s", expected,
+			prepared)
+	}
+}
+
+func TestPrepareKeepsGuidanceAboveSnipLine(t *testing.T) {
+	msg := "\n# " + snipLine + "\ndiff --git a/file b/file\n"
+	prepConf := prepareConf{guidance: true}
+	prepared := prepareMsg(msg, defaultCleanup, prepConf, []rules.Interface{rules.SubjLen})
+
+	expected := "\n# commitfmt will check this message against the following " +
+		"rules:\n#   " + ruleString(rules.SubjLen) + "\n# " + snipLine +
+		"\ndiff --git a/file b/file\n"
+	if prepared != expected {
+		t.Errorf("Expected prepared message:\n%s\nbut got:\n%s", expected,
+			prepared)
+	}
+}
+
+func TestPrepareGuidanceWithoutStrippedComments(t *testing.T) {
+	msg := "\n# " + snipLine + "\ndiff --git a/file b/file\n"
+	prepConf := prepareConf{guidance: true}
+	enabled := []rules.Interface{rules.SubjLen}
+	for _, mode := range []string{"scissors", "whitespace", "verbatim"} {
+		c := cleanup{mode: mode, commentChar: defaultCommentChar}
+		prepared := prepareMsg(msg, c, prepConf, enabled)
+		if cleaned := cleanMsg(prepared, c); strings.Contains(cleaned,
+			"commitfmt will check") {
+			t.Errorf("Expected no guidance in the %s message but got:\n%s",
+				mode, cleaned)
+		}
+	}
+
+	c := cleanup{mode: "scissors", commentChar: defaultCommentChar}
+	expected := "\n# " + snipLine + "\n# commitfmt will check this message " +
+		"against the following rules:\n#   " + ruleString(rules.SubjLen) +
+		"\ndiff --git a/file b/file\n"
+	if prepared := prepareMsg(msg, c, prepConf, enabled); prepared != expected {
+		t.Errorf("Expected prepared message:\n%s\nbut got:\n%s", expected,
+			prepared)
+	}
+}
+
+func TestAnnotations(t *testing.T) {
+	msg := "Subject that ends with a period.\n"
+	_, rep, _ := lint(msg, nil, defaultCleanup)
+	anns := annotations(rep, "#")
+
+	header := "#   [1:32] subj-no-period: " + rules.SubjNoPeriod.Desc()
+	if anns[3] != header {
+		t.Errorf("Expected annotation:\n%s\nbut got:\n%s", header, anns[3])
+	}
+	if anns[len(anns)-1] != "#" {
+		t.Error("Expected annotations to end with a blank comment")
+	}
+}
+
+func TestStripAnnotations(t *testing.T) {
+	anns := []string{"# First annotation", "#"}
+	edited := "# First annotation\r\n#\nFixed subject\n\n#\n# Git comment\n"
+
+	expected := "Fixed subject\n\n#\n# Git comment\n"
+	if stripped := stripAnnotations(edited, anns); stripped != expected {
+		t.Errorf("Expected stripped message:\n%q\nbut got:\n%q", expected,
+			stripped)
+	}
+}
+
+func TestPrepareForgetsReusedMessage(t *testing.T) {
+	defer inTempRepo(t)()
+	saved, err := saveRejected("Bad subject.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, "COMMIT_EDITMSG", "Another subject\n")
+	prepare([]string{"COMMIT_EDITMSG", "message"})
+	if loadRejected() == "" {
+		t.Fatal("Expected the rejected message to be kept")
+	}
+
+	writeFile(t, "COMMIT_EDITMSG", "Bad subject.\n\n# Please enter the "+
+		"commit message for your changes.\n")
+	prepare([]string{"COMMIT_EDITMSG", "message"})
+	if rejected := loadRejected(); rejected != "" {
+		t.Errorf("Expected %s to be forgotten but got %q", saved, rejected)
+	}
+}
+
+func TestReadPrepareConfRestore(t *testing.T) {
+	if !readPrepareConf(nil).restore {
+		t.Error("Expected rejected messages to be restored by default")
+	}
+
+	conf := map[string]interface{}{
+		"prepare": map[string]interface{}{"restore": false},
+	}
+	if readPrepareConf(conf).restore {
+		t.Error("Expected restoring rejected messages to be disabled")
+	}
+}
+
+// exampleConf returns a conf that disables every rule except for rule, which
+// is configured with the settings its examples assume.
+func exampleConf(rule rules.Interface, d rules.Doc) map[string]interface{} {
+	conf := map[string]interface{}{}
+	for _, r := range rules.All {
+		conf[r.Name()] = false
+	}
+	conf[rule.Name()] = true
+	if d.ExampleConf != nil {
+		conf[rule.Name()] = d.ExampleConf
+	}
+	return conf
+}
+
+func TestRuleExamples(t *testing.T) {
+	defer func() {
+		rules.SubjRegex.Config(rules.SubjRegex.DefaultConf)
+		rules.IssueRef.Config(rules.IssueRef.DefaultConf)
+		rules.BreakingChange.Config(rules.BreakingChange.DefaultConf)
+		rules.SubjMin.Config(rules.SubjMin.DefaultConf)
+		rules.Env.Files = nil
+	}()
+
+	for _, rule := range rules.All {
+		doc, ok := rule.(rules.Documented)
+		if !ok {
+			t.Errorf("Rule %s isn't documented", rule.Name())
+			continue
+		}
+
+		d := doc.Doc()
+		conf := exampleConf(rule, d)
+		rules.Env.Files = d.ExampleFiles
+		for _, msg := range d.Good {
+			if rep, _ := runRules(msg, conf); rep.violations != nil {
+				t.Errorf("Unexpected violations in good example for %s: %s",
+					rule.Name(), rep.string())
+			}
+		}
+		for _, msg := range d.Bad {
+			if rep, _ := runRules(msg, conf); !reportHasViolation(rep, rule) {
+				t.Errorf("Expected violation in bad example for %s: %q",
+					rule.Name(), msg)
+			}
+		}
+	}
+}
+
+func TestExplainUnknownRule(t *testing.T) {
+	if rule := findRule("not-a-rule"); rule != nil {
+		t.Error("Expected no rule but got", rule.Name())
+	}
+}
+
+func TestExplainRule(t *testing.T) {
+	conf := map[string]interface{}{
+		"subj-len": map[string]interface{}{
+			"unit": "runes",
+		},
+	}
+	defer func() { rules.SubjLen.Config(rules.SubjLen.DefaultConf) }()
+	enabled, err := configure(conf)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	explanation := explainRule(rules.SubjLen, conf, enabled)
+
+	if !strings.HasPrefix(explanation, "subj-len (enabled)\n") {
+		t.Error("Expected the rule to be enabled:", explanation)
+	}
+	if !strings.Contains(explanation, `Configured value: "runes"`) {
+		t.Error("Expected the configured unit:", explanation)
+	}
+	if !strings.Contains(explanation, "\tseverity - ") {
+		t.Error("Expected the severity setting:", explanation)
+	}
+}
+
+func TestDocsAreUpToDate(t *testing.T) {
+	tmpl, err := ioutil.ReadFile("docs/rules.md.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing, err := ioutil.ReadFile("docs/rules.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rendered, err := renderDocs(string(tmpl))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered != string(existing) {
+		t.Error("docs/rules.md is out of date, run go generate to update it")
+	}
+}
+
+func Example_longSubject() {
 	msg := "This subject is longer than 50 characters and will trigger an error"
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 	fmt.Println(rep.string())
 
-	// Output: [1:51] subj-len: the subject should not exceed 50 characters.
+	// Output: [1:51] subj-len: the subject should not exceed 50 characters. The subject is 67 characters, 17 over the limit of 50.
 	// 	This subject is longer than 50 characters and will trigger an error
-	// 	                                                  ^
+	// 	                                                  ^~~~~~~~~~~~~~~~~
 	// 1 formatting errors were found.
 }
 
-func Example2() {
+func Example_multipleViolations() {
 	msg := `This commit message has a Number of different violations that will be caught.
 
 The body is way too long and goes beyond 72 characters per line.  There are unnecessary spaces
 in between words and the body doesn't end with punctuation`
-	rep := runRules(msg, nil)
+	rep, _ := runRules(msg, nil)
 	fmt.Println(rep.string())
 
-	// Output: [1:27] subj-sentence-case: the subject should adhere to sentence casing, i.e., only the first letter of the first word should be capitalized.
-	// 	This commit message has a Number of different violations that will be caught.
-	// 	                          ^
-	// [1:51] subj-len: the subject should not exceed 50 characters.
-	// 	This commit message has a Number of different violations that will be caught.
-	// 	                                                  ^
+	// Output: [1:27] subj-sentence-case: the subject should adhere to sentence casing, i.e., only the first letter of the first word should be capitalized. The word "Number" should be lowercase.
+	// [1:51] subj-len: the subject should not exceed 50 characters. The subject is 77 characters, 27 over the limit of 50.
 	// [1:77] subj-no-period: the subject should not end with a period.
 	// 	This commit message has a Number of different violations that will be caught.
-	// 	                                                                            ^
-	// [3:65] whitespace: there should not be any unnecessary spacing, i.e., only one line break between paragraphs, only one space between words, and no trailing whitespace.
+	// 	                          ^~~~~~                  ^~~~~~~~~~~~~~~~~~~~~~~~~~^
+	// [3:66] whitespace: there should not be any unnecessary spacing, i.e., only one line break between paragraphs, only one space between words, and no trailing whitespace. There are 2 spaces in a row.
+	// [3:73] body-len: each line of the body should not exceed 72 characters. The line is 94 characters, 22 over the limit of 72.
 	// 	The body is way too long and goes beyond 72 characters per line.  There are unnecessary spaces
-	// 	                                                                ^
-	// [3:72] body-len: each line of the body should not exceed 72 characters.
-	// 	The body is way too long and goes beyond 72 characters per line.  There are unnecessary spaces
-	// 	                                                                       ^
-	// [4:58] body-punc: the body should end with valid punctuation (".", "!", "?") unless it ends with a list.
+	// 	                                                                 ^      ^~~~~~~~~~~~~~~~~~~~~~
+	// [4:59] body-punc: the body should end with valid punctuation (".", "!", "?") unless it ends with a list.
 	// 	in between words and the body doesn't end with punctuation
-	// 	                                                         ^
+	// 	                                                          ^
 	// 6 formatting errors were found.
 }
+
+func Example_wideCharacters() {
+	msg := "Add 日本語 support."
+	rep, _ := runRules(msg, nil)
+	fmt.Println(rep.string())
+
+	// Output: [1:16] subj-no-period: the subject should not end with a period.
+	// 	Add 日本語 support.
+	// 	                  ^
+	// 1 formatting errors were found.
+}
+
+func Example_color() {
+	msg := "Subject that ends with a period."
+	rep, _ := runRules(msg, nil)
+	rep.color = true
+	fmt.Printf("%q\n", rep.string())
+
+	// Output: "\x1b[36m[1:32]\x1b[0m \x1b[1m\x1b[31msubj-no-period\x1b[0m: the subject should not end with a period.\n\tSubject that ends with a period\x1b[4m\x1b[31m.\x1b[0m\n1 formatting errors were found."
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gcurtis/commitfmt/rules"
+)
+
+// pathsConfName is the name of the conf file section that overrides rule
+// settings for commits that change certain files.
+const pathsConfName = "paths"
+
+// confForPaths returns conf with the overrides for every path pattern that
+// matches at least one of the changed files applied. Patterns are matched with
+// rules.MatchGlob. When several patterns match, the most specific pattern is
+// applied last so that its settings take precedence.
+func confForPaths(conf map[string]interface{},
+	files []string) map[string]interface{} {
+	patterns, ok := conf[pathsConfName].(map[string]interface{})
+	if !ok || len(files) == 0 {
+		return conf
+	}
+
+	var matches []string
+	for pattern := range patterns {
+		if rules.MatchAnyGlob(pattern, files) {
+			matches = append(matches, pattern)
+		}
+	}
+
+	for _, pattern := range sortBySpecificity(matches) {
+		if override, ok := patterns[pattern].(map[string]interface{}); ok {
+			conf = mergeConf(conf, override)
+		}
+	}
+	return conf
+}
+
+// stagedChanges returns the paths of the files that are staged to be committed
+// and the number of lines they add and remove. Git points GIT_INDEX_FILE at the
+// index being committed when it runs hooks, so this also works for
+// "git commit -a" and "git commit <paths>".
+func stagedChanges() (files []string, lines int) {
+	return diffStat("diff", "--cached", "--numstat", "-z", "--no-renames")
+}
+
+// commitChanges returns the paths of the files changed by a commit and the
+// number of lines it adds and removes. Merge commits don't list any changes
+// since git doesn't diff them against a single parent.
+func commitChanges(sha string) (files []string, lines int) {
+	return diffStat("diff-tree", "--no-commit-id", "--numstat", "-r", "-z",
+		"--no-renames", "--root", sha)
+}
+
+// diffStat runs a git command that prints NUL-separated "--numstat" output and
+// returns the files it lists and the total number of changed lines.
+func diffStat(args ...string) (files []string, lines int) {
+	out, err := git(args...)
+	if err != nil {
+		return nil, 0
+	}
+
+	for _, entry := range strings.Split(out, "\x00") {
+		fields := strings.SplitN(entry, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		added, _ := strconv.Atoi(fields[0])
+		removed, _ := strconv.Atoi(fields[1])
+		lines += added + removed
+		files = append(files, fields[2])
+	}
+	return files, lines
+}
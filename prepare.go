@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/gcurtis/commitfmt/rules"
+)
+
+// prepareConfName is the name of the conf file section that configures the
+// prepare command.
+const prepareConfName = "prepare"
+
+// ticketPlaceholder is replaced with the issue key found in the branch name
+// when the template is inserted.
+const ticketPlaceholder = "{ticket}"
+
+// prepareConf contains the settings for the prepare command.
+type prepareConf struct {
+	template string // template is inserted at the start of new messages.
+	guidance bool   // guidance enables comments that list the active rules.
+	restore  bool   // restore pre-fills the last rejected message.
+}
+
+// prepare pre-fills the commit message in the file at args[0] with the
+// configured template and a list of the active rules. It's meant to be run as
+// git's prepare-commit-msg hook, so args should be the arguments that git
+// passes to the hook: the message file, the message's source and an optional
+// commit SHA.
+//
+// Messages that come from a merge, squash, amend or the -m/-F flags are left
+// untouched since they already contain the user's intended message. If the
+// previous commit was rejected by the commit-msg hook, its message is restored
+// in place of the template. A rejected message that's passed back to git with
+// -F is forgotten, since the commit-msg hook may be bypassed with --no-verify.
+func prepare(args []string) {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "You must provide a path to a file containing"+
+			" the commit message.")
+		os.Exit(1)
+	}
+
+	path := args[0]
+	source := ""
+	if len(args) > 1 {
+		source = args[1]
+	}
+	if source == "message" {
+		forgetIfReused(path)
+		return
+	}
+	if source != "" && source != "template" {
+		return
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't open file \"%s\".\n", path)
+		os.Exit(1)
+	}
+
+	rules.Env.Branch = currentBranch()
+	rules.Env.Files, rules.Env.Lines = stagedChanges()
+	conf, skip := confForBranch(readConf(), rules.Env.Branch)
+	conf = confForPaths(conf, rules.Env.Files)
+	prepConf := readPrepareConf(conf)
+	if source == "template" {
+		prepConf.template = ""
+	}
+
+	msg := string(b)
+	if rejected := loadRejected(); prepConf.restore && source == "" &&
+		rejected != "" {
+		prepConf.template = ""
+		msg = rejected + msg
+		forgetRejected()
+	}
+	var enabled []rules.Interface
+	if !skip {
+		enabled, err = configure(conf)
+		exitOnConfError(err)
+	}
+	msg = prepareMsg(msg, readCleanup(msg), prepConf, enabled)
+	err = ioutil.WriteFile(path, []byte(msg), 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't write file \"%s\".\n", path)
+		os.Exit(1)
+	}
+}
+
+// readPrepareConf reads the prepare command's settings from conf. Any settings
+// that are missing or invalid are left at their defaults.
+func readPrepareConf(conf map[string]interface{}) prepareConf {
+	prepConf := prepareConf{guidance: true, restore: true}
+	settings, ok := conf[prepareConfName].(map[string]interface{})
+	if !ok {
+		return prepConf
+	}
+
+	if template, ok := settings["template"].(string); ok {
+		prepConf.template = template
+	}
+	if guidance, ok := settings["guidance"].(bool); ok {
+		prepConf.guidance = guidance
+	}
+	if restore, ok := settings["restore"].(bool); ok {
+		prepConf.restore = restore
+	}
+	return prepConf
+}
+
+// prepareMsg inserts the template at the start of a message and a commented
+// list of the enabled rules. The list goes above the cut line when the cleanup
+// mode strips comments. In the "scissors" mode, comments are kept, so it goes
+// below the cut line instead. Other modes keep everything, so the list is left
+// out since it would end up in the commit.
+func prepareMsg(msg string, c cleanup, prepConf prepareConf,
+	enabled []rules.Interface) string {
+	buf := bytes.Buffer{}
+	if prepConf.template != "" {
+		ticket := rules.BranchKey(rules.Env.Branch)
+		buf.WriteString(strings.Replace(prepConf.template, ticketPlaceholder,
+			ticket, -1))
+	}
+
+	snip := c.cutIndex(msg)
+	if c.mode == "scissors" && snip < len(msg) {
+		// Insert the list after the cut line itself.
+		if end := strings.Index(msg[snip:], "\n"); end != -1 {
+			snip += end + 1
+		} else {
+			snip = len(msg)
+		}
+	} else if !c.stripComments() {
+		prepConf.guidance = false
+	}
+	buf.WriteString(msg[:snip])
+
+	if prepConf.guidance && len(enabled) > 0 {
+		if !strings.HasSuffix(msg[:snip], "\n") {
+			buf.WriteRune('\n')
+		}
+		buf.WriteString(guidance(enabled, c.commentChar))
+	}
+
+	buf.WriteString(msg[snip:])
+	return buf.String()
+}
+
+// guidance returns a block of comments that describe the enabled rules.
+func guidance(enabled []rules.Interface, commentChar string) string {
+	buf := bytes.Buffer{}
+	fmt.Fprintf(&buf, "%s commitfmt will check this message against the "+
+		"following rules:\n", commentChar)
+	for _, rule := range enabled {
+		fmt.Fprintf(&buf, "%s   %s\n", commentChar, ruleString(rule))
+	}
+	return buf.String()
+}
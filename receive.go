@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gcurtis/commitfmt/rules"
+)
+
+// branchRefPrefix is the prefix of the full names of refs that are branches.
+const branchRefPrefix = "refs/heads/"
+
+// preReceive checks the messages of every commit in a push. It's meant to be
+// run as git's pre-receive hook on a server, so it reads a line for each pushed
+// ref from stdin in the form "<old-sha> <new-sha> <ref-name>". The push is
+// rejected if any of the messages have violations.
+func preReceive() {
+	conf := readConf()
+	failed := 0
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+		failed += checkRef(conf, fields[2], fields[0], fields[1])
+	}
+	rejectPush(failed)
+}
+
+// update checks the messages of every commit pushed to a single ref. It's meant
+// to be run as git's update hook on a server, so args should be the ref's name,
+// its old SHA and its new SHA.
+func update(args []string) {
+	if len(args) < 3 {
+		fmt.Fprintln(os.Stderr, "You must provide a ref name, its old SHA and "+
+			"its new SHA.")
+		os.Exit(1)
+	}
+	rejectPush(checkRef(readConf(), args[0], args[1], args[2]))
+}
+
+// rejectPush exits with an error if any commits failed the check.
+func rejectPush(failed int) {
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "The push was rejected because %d commits have "+
+			"formatting errors.\n", failed)
+		os.Exit(1)
+	}
+}
+
+// checkRef checks the messages of the commits that a push adds to a ref and
+// prints a report for each one with violations. The number of commits with
+// violations is returned.
+func checkRef(conf map[string]interface{}, ref string, oldSHA string,
+	newSHA string) (failed int) {
+	if isZeroSHA(newSHA) {
+		return 0
+	}
+
+	commits, err := pushedCommits(ref, oldSHA, newSHA)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't list the commits pushed to %s.\n", ref)
+		return 1
+	}
+
+	rules.Env.Branch = refBranch(ref)
+	color := useColor()
+	for _, sha := range commits {
+		msg, err := git("log", "-1", "--format=%B", sha)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Couldn't read the message of commit %s.\n",
+				sha)
+			failed++
+			continue
+		}
+
+		rules.Env.Files, rules.Env.Lines = commitChanges(sha)
+		rep, err := runRules(msg, conf)
+		exitOnConfError(err)
+		// Comment directives were stripped by git, so only trailers remain.
+		rep.suppress(parseTrailerDirectives(msg), readRequireReason(conf))
+		if len(rep.violations) == 0 {
+			continue
+		}
+		rep.color = color
+		fmt.Printf("Commit %s on %s:\n%s\n\n", sha, ref, rep.string())
+		if rep.failed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// pushedCommits returns the commits that a push adds to a ref, from oldest to
+// newest. Commits that are already reachable from another ref were checked when
+// they were pushed, so they're skipped whether the ref is being created or
+// updated. This keeps a merge from another branch from re-checking its commits.
+func pushedCommits(ref string, oldSHA string, newSHA string) ([]string,
+	error) {
+	args := []string{"rev-list", "--reverse", newSHA}
+	if !isZeroSHA(oldSHA) {
+		args = append(args, "^"+oldSHA)
+	}
+	args = append(args, "--not", "--exclude="+ref, "--all")
+
+	out, err := git(args...)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// isZeroSHA returns true if a SHA is all zeros, which git uses to mean that a
+// ref is being created or deleted.
+func isZeroSHA(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
+
+// refBranch returns the name of the branch that a ref points to, or an empty
+// string if the ref isn't a branch.
+func refBranch(ref string) string {
+	if !strings.HasPrefix(ref, branchRefPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(ref, branchRefPrefix)
+}
@@ -9,10 +9,27 @@ import (
 	"github.com/gcurtis/commitfmt/rules"
 )
 
+// ANSI escape codes used to color the report.
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiUnderline = "\x1b[4m"
+	ansiRed       = "\x1b[31m"
+	ansiYellow    = "\x1b[33m"
+	ansiCyan      = "\x1b[36m"
+)
+
 // report contains a list of rules that were violated in a commit message.
 type report struct {
+	color      bool              // color enables ANSI colors in the report.
 	msg        string            // msg is the commit message.
 	violations []rules.Violation // violations is a list of rule violations.
+	suppressed []suppression     // suppressed lists rules disabled by directives.
+	notes      []string          // notes are extra messages for the user.
+
+	// warnings are the rules whose violations are reported as warnings, which
+	// don't cause the check to fail.
+	warnings map[rules.Interface]bool
 }
 
 // append adds a violation to the report.
@@ -20,62 +37,239 @@ func (rep *report) append(v ...rules.Violation) {
 	rep.violations = append(rep.violations, v...)
 }
 
-// string creates a human-readable string from the report.
+// string creates a human-readable string from the report. Violations that
+// occur on the same line are grouped together under a single context string.
 func (rep *report) string() string {
 	sort.Sort(rep)
 	str := ""
+	for i := 0; i < len(rep.violations); {
+		lineStart, _, _ := rep.lineChar(rep.violations[i].Pos)
+		var group []rules.Violation
+		for ; i < len(rep.violations); i++ {
+			v := rep.violations[i]
+			if start, _, _ := rep.lineChar(v.Pos); start != lineStart {
+				break
+			}
+
+			str += rep.header(v) + "\n"
+			group = append(group, v)
+		}
+		str += rep.context(lineStart, group, "\t") + "\n"
+	}
+	for _, note := range rep.notes {
+		str += fmt.Sprintf("Note: %s\n", note)
+	}
+	if len(rep.suppressed) > 0 {
+		str += "The following rules were disabled for this message:\n"
+		for _, s := range rep.suppressed {
+			if s.reason != "" {
+				str += fmt.Sprintf("\t%s: %s\n", s.rule, s.reason)
+			} else {
+				str += fmt.Sprintf("\t%s\n", s.rule)
+			}
+		}
+	}
+	if warnings := len(rep.violations) - rep.errors(); warnings > 0 {
+		str += fmt.Sprintf("%d formatting errors and %d warnings were found.",
+			rep.errors(), warnings)
+	} else {
+		str += fmt.Sprintf("%d formatting errors were found.",
+			len(rep.violations))
+	}
+
+	return str
+}
+
+// errors returns the number of violations that aren't warnings.
+func (rep *report) errors() int {
+	errors := 0
 	for _, v := range rep.violations {
-		lineStart, lineNum, charNum := rep.lineChar(v.Pos)
-		ruleStr := ruleString(v.Rule)
-		context := rep.context(lineStart, charNum, "\t")
-		str += fmt.Sprintf("[%d:%d] %s\n%s\n", lineNum, charNum, ruleStr,
-			context)
+		if !rep.warnings[v.Rule] {
+			errors++
+		}
 	}
-	str += fmt.Sprintf("%d formatting errors were found.", len(rep.violations))
+	return errors
+}
+
+// failed returns true if the report has any violations that aren't warnings.
+func (rep *report) failed() bool {
+	return rep.errors() > 0
+}
 
+// header returns the location of a violation followed by the name and
+// description of the rule that was violated.
+func (rep *report) header(v rules.Violation) string {
+	_, lineNum, charNum := rep.lineChar(v.Pos)
+	loc := rep.colorize(ansiCyan, fmt.Sprintf("[%d:%d]", lineNum, charNum))
+	name := rep.colorize(ansiBold+ansiRed, v.Rule.Name())
+	if rep.warnings[v.Rule] {
+		name = rep.colorize(ansiBold+ansiYellow, v.Rule.Name()) + " (warning)"
+	}
+	str := fmt.Sprintf("%s %s: %s", loc, name, v.Rule.Desc())
+	if v.Msg != "" {
+		str += " " + v.Msg
+	}
 	return str
 }
 
 // lineChar takes a position in the commit message and returns the starting
 // point of the line that the position is on, the position's line number and the
-// position's character number.
+// position's character number. Characters are counted as runes rather than
+// bytes.
 func (rep *report) lineChar(pos int) (lineStart int, lineNum int, charNum int) {
 	lineNum = 1
 	charNum = 1
-	for i := 0; i < pos; i++ {
-		if rep.msg[i] == '\n' {
+	for i, c := range rep.msg {
+		if i >= pos {
+			break
+		}
+
+		if c == '\n' {
 			lineNum++
 			lineStart = i + 1
-			charNum = 0
+			charNum = 1
 		} else {
 			charNum++
 		}
 	}
+	if pos > len(rep.msg) {
+		charNum += pos - len(rep.msg)
+	}
 	return
 }
 
-// context creates a "context string" that points to where the error occurred
-// within the commit message.
-func (rep *report) context(lineStart int, charNum int, prefix string) string {
+// lineNum returns the number of the line that a position is on, starting from
+// 1.
+func (rep *report) lineNum(pos int) int {
+	_, lineNum, _ := rep.lineChar(pos)
+	return lineNum
+}
+
+// context creates a "context string" that points to where errors occurred on a
+// line of the commit message. The first character of each violation is marked
+// with a "^" and the rest of its characters are marked with a "~". The marks
+// are aligned using the display width of the characters that come before them.
+// If colors are enabled, the characters are underlined instead, in yellow if
+// every violation is a warning.
+func (rep *report) context(lineStart int, violations []rules.Violation,
+	prefix string) string {
 	line := rep.msg[lineStart:]
 	index := strings.Index(line, "\n")
 	if index != -1 {
 		line = line[:index]
 	}
+	line = strings.TrimSuffix(line, "\r")
+
+	marks, end := spanMarks(violations, lineStart+len(line))
+	if rep.color {
+		code := ansiUnderline + ansiYellow
+		for _, v := range violations {
+			if !rep.warnings[v.Rule] {
+				code = ansiUnderline + ansiRed
+			}
+		}
+		return prefix + rep.underline(line, lineStart, end, marks, code)
+	}
 
 	buf := bytes.Buffer{}
 	buf.WriteString(prefix)
 	buf.WriteString(line)
 	buf.WriteRune('\n')
 	buf.WriteString(prefix)
-	for i := 0; i < charNum-1; i++ {
-		buf.WriteRune(' ')
+	padding := bytes.Buffer{}
+	mark := func(pos int, c rune) {
+		m, ok := marks[pos]
+		if !ok {
+			if c == '\t' {
+				padding.WriteRune('\t')
+			} else {
+				padding.WriteString(strings.Repeat(" ", rules.RuneWidth(c)))
+			}
+			return
+		}
+
+		buf.Write(padding.Bytes())
+		padding.Reset()
+		buf.WriteRune(m)
+		if w := rules.RuneWidth(c); w > 1 {
+			buf.WriteString(strings.Repeat("~", w-1))
+		}
+	}
+
+	for i, c := range line {
+		mark(lineStart+i, c)
+	}
+	for i := lineStart + len(line); i < end; i++ {
+		mark(i, ' ')
+	}
+
+	return buf.String()
+}
+
+// spanMarks returns the mark for every position covered by a line's
+// violations. Spans are cut off at the end of the line, but a violation can
+// still point just past the last character. The returned end is the position
+// just past the last mark.
+func spanMarks(violations []rules.Violation, lineEnd int) (
+	marks map[int]rune, end int) {
+	marks = map[int]rune{}
+	for _, v := range violations {
+		spanEnd := v.End
+		if spanEnd <= v.Pos {
+			spanEnd = v.Pos + 1
+		}
+		if spanEnd > lineEnd && v.Pos < lineEnd {
+			spanEnd = lineEnd
+		}
+
+		marks[v.Pos] = '^'
+		for i := v.Pos + 1; i < spanEnd; i++ {
+			if _, ok := marks[i]; !ok {
+				marks[i] = '~'
+			}
+		}
+		if spanEnd > end {
+			end = spanEnd
+		}
+	}
+	return
+}
+
+// underline returns a line with the characters at the marked positions
+// underlined in the color given by code. Positions past the end of the line are
+// underlined as spaces.
+func (rep *report) underline(line string, lineStart int, end int,
+	marks map[int]rune, code string) string {
+	buf := bytes.Buffer{}
+	for i, c := range line {
+		if _, ok := marks[lineStart+i]; ok {
+			buf.WriteString(rep.colorize(code, string(c)))
+		} else {
+			buf.WriteRune(c)
+		}
 	}
-	buf.WriteRune('^')
 
+	padding := ""
+	for i := lineStart + len(line); i < end; i++ {
+		if _, ok := marks[i]; ok {
+			buf.WriteString(padding)
+			buf.WriteString(rep.colorize(code, " "))
+			padding = ""
+		} else {
+			padding += " "
+		}
+	}
 	return buf.String()
 }
 
+// colorize wraps a string in an ANSI escape code if colors are enabled.
+func (rep *report) colorize(code string, s string) string {
+	if !rep.color {
+		return s
+	}
+	return code + s + ansiReset
+}
+
 // Len satisfies sort.Interface.
 func (rep *report) Len() int {
 	return len(rep.violations)
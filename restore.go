@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// rejectedPath returns the path that the last rejected commit message is saved
+// to. It's inside of the repo's git directory so that it isn't tracked and
+// doesn't leak between repos.
+func rejectedPath() (string, error) {
+	gitDir, err := git("rev-parse", "--git-dir")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(gitDir, "commitfmt", "last-message"), nil
+}
+
+// saveRejected saves a message that failed the check so that it can be
+// restored later and returns the path it was saved to. If the current
+// directory isn't inside of a git repo, the message is saved to a temporary
+// file instead.
+func saveRejected(msg string) (string, error) {
+	if !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
+
+	path, err := rejectedPath()
+	if err != nil {
+		f, err := ioutil.TempFile("", "commitfmt")
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		_, err = f.WriteString(msg)
+		return f.Name(), err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	return path, ioutil.WriteFile(path, []byte(msg), 0644)
+}
+
+// loadRejected returns the last rejected commit message. An empty string is
+// returned if there isn't one.
+func loadRejected() string {
+	path, err := rejectedPath()
+	if err != nil {
+		return ""
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// forgetRejected removes the last rejected commit message, if there is one.
+func forgetRejected() {
+	if path, err := rejectedPath(); err == nil {
+		os.Remove(path)
+	}
+}
+
+// forgetIfReused forgets the last rejected commit message if the message file
+// at path contains it. This happens when the rejected message is committed with
+// "git commit -F", which is how the commit-msg hook suggests bypassing the
+// check.
+func forgetIfReused(path string) {
+	rejected := loadRejected()
+	b, err := ioutil.ReadFile(path)
+	if rejected == "" || err != nil {
+		return
+	}
+
+	msg := string(b)
+	c := readCleanup(msg)
+	if cleanMsg(msg, c) == cleanMsg(rejected, c) {
+		forgetRejected()
+	}
+}
+
+// restore restores the last rejected commit message. If a message file is
+// given in args[0], the rejected message is inserted at the start of it and
+// then forgotten. Otherwise, the rejected message is printed.
+func restore(args []string) {
+	rejected := loadRejected()
+	if rejected == "" {
+		fmt.Fprintln(os.Stderr, "There isn't a rejected commit message to "+
+			"restore.")
+		os.Exit(1)
+	}
+
+	if len(args) < 1 {
+		fmt.Print(rejected)
+		return
+	}
+
+	path := args[0]
+	b, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Couldn't open file \"%s\".\n", path)
+		os.Exit(1)
+	}
+
+	err = ioutil.WriteFile(path, []byte(rejected+string(b)), 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't write file \"%s\".\n", path)
+		os.Exit(1)
+	}
+	forgetRejected()
+}
@@ -0,0 +1,293 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Locations in a commit message that banned words are searched for.
+const (
+	locSubject  = "subject"
+	locBody     = "body"
+	locTrailers = "trailers"
+)
+
+// allLocations is every location that banned words are searched for by default.
+var allLocations = []string{locSubject, locBody, locTrailers}
+
+// BannedWords checks that the message doesn't contain any of the words or
+// regexes configured via the "words" and "patterns" settings. Entries are
+// searched for in the locations given by the "locations" setting and are case
+// insensitive unless the "case-sensitive" setting is true. An entry can also be
+// a map with its own "message", "locations" and "case-sensitive" settings.
+var BannedWords = &bannedWords{
+	DefaultConf: map[string]interface{}{
+		"words":          nil,
+		"patterns":       nil,
+		"locations":      nil,
+		"case-sensitive": nil,
+	},
+	locations: allLocations,
+}
+
+type bannedWords struct {
+	DefaultConf   map[string]interface{}
+	words         []interface{}
+	patterns      []interface{}
+	locations     []string
+	caseSensitive bool
+	entries       []bannedEntry
+}
+
+// bannedEntry is a single word or pattern that isn't allowed in the message.
+type bannedEntry struct {
+	regex     *regexp.Regexp // regex matches the banned text.
+	msg       string         // msg optionally replaces the default message.
+	locations []string       // locations are where the entry is searched for.
+}
+
+func (rule *bannedWords) Name() string {
+	return "banned-words"
+}
+
+func (rule *bannedWords) Desc() string {
+	return "the message should not contain banned words or phrases."
+}
+
+func (rule *bannedWords) Doc() Doc {
+	return Doc{
+		Category: CategoryGeneral,
+		Details: "Words only match whole words, so banning \"misc\" doesn't " +
+			"ban \"miscellaneous\". Each entry in \"words\" or \"patterns\" " +
+			"can be a string or a map with a \"word\" or \"pattern\" key and " +
+			"optional \"message\", \"locations\" and \"case-sensitive\" keys " +
+			"that override the rule's settings for that entry. This rule is " +
+			"skipped unless words or patterns are configured.",
+		Settings: []Setting{
+			{
+				Name: "words",
+				Desc: "a list of words and phrases that aren't allowed.",
+			},
+			{
+				Name: "patterns",
+				Desc: "a list of regexes that aren't allowed to match.",
+			},
+			{
+				Name: "locations",
+				Desc: "where to search: any of \"subject\", \"body\" and " +
+					"\"trailers\". Defaults to all three.",
+			},
+			{
+				Name: "case-sensitive",
+				Desc: "if true, words and patterns must match the case of " +
+					"the message. Defaults to false.",
+			},
+		},
+		Good: []string{"Fix the layout of the miscellaneous settings page"},
+		Bad: []string{
+			"WIP: Fix the settings page",
+			"Fix stuff",
+			"Fix the settings page\n\nThis is part of Project Falcon.",
+		},
+		ExampleConf: map[string]interface{}{
+			"words": []interface{}{
+				"wip", "misc", "stuff",
+				map[string]interface{}{
+					"word":    "project falcon",
+					"message": "Don't mention internal codenames.",
+				},
+			},
+		},
+	}
+}
+
+func (rule *bannedWords) Config(conf map[string]interface{}) (err error) {
+	if inter, ok := conf["words"]; ok {
+		rule.words, _ = inter.([]interface{})
+		if inter != nil && rule.words == nil {
+			return fmt.Errorf("the words setting must be a list")
+		}
+	}
+
+	if inter, ok := conf["patterns"]; ok {
+		rule.patterns, _ = inter.([]interface{})
+		if inter != nil && rule.patterns == nil {
+			return fmt.Errorf("the patterns setting must be a list")
+		}
+	}
+
+	if inter, ok := conf["locations"]; ok {
+		rule.locations = allLocations
+		if inter != nil {
+			rule.locations, err = locationsSetting(inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if inter, ok := conf["case-sensitive"]; ok {
+		rule.caseSensitive = false
+		if inter != nil {
+			rule.caseSensitive, ok = inter.(bool)
+			if !ok {
+				return fmt.Errorf("the case-sensitive setting must be true " +
+					"or false")
+			}
+		}
+	}
+
+	rule.entries, err = rule.compile()
+	return
+}
+
+// compile converts the configured words and patterns into entries. It's done
+// after every setting has been read since the entries depend on the rule-wide
+// locations and case sensitivity.
+func (rule *bannedWords) compile() ([]bannedEntry, error) {
+	var entries []bannedEntry
+	for _, setting := range []struct {
+		key    string
+		values []interface{}
+	}{{"word", rule.words}, {"pattern", rule.patterns}} {
+		for _, value := range setting.values {
+			entry, err := rule.compileEntry(setting.key, value)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// compileEntry converts a single word or pattern into an entry. key is either
+// "word" or "pattern".
+func (rule *bannedWords) compileEntry(key string, value interface{}) (
+	entry bannedEntry, err error) {
+	entry.locations = rule.locations
+	caseSensitive := rule.caseSensitive
+
+	text, ok := value.(string)
+	if m, isMap := value.(map[string]interface{}); isMap {
+		text, ok = m[key].(string)
+		if inter, set := m["message"]; set {
+			if entry.msg, err = stringSetting("message", inter); err != nil {
+				return
+			}
+		}
+		if inter, set := m["locations"]; set {
+			if entry.locations, err = locationsSetting(inter); err != nil {
+				return
+			}
+		}
+		if inter, set := m["case-sensitive"]; set {
+			if caseSensitive, set = inter.(bool); !set {
+				err = fmt.Errorf("the case-sensitive setting must be true " +
+					"or false")
+				return
+			}
+		}
+	}
+	if !ok || text == "" {
+		err = fmt.Errorf("every %s must be a string or a map with a \"%s\" "+
+			"key", key, key)
+		return
+	}
+
+	expr := text
+	if key == "word" {
+		expr = wordRegexp(text)
+	}
+	if !caseSensitive {
+		expr = "(?i)" + expr
+	}
+
+	entry.regex, err = regexp.Compile(expr)
+	if err != nil {
+		err = fmt.Errorf("the patterns must be valid regular expressions")
+	}
+	return
+}
+
+// wordRegexp returns a regex that matches a word or phrase on its own. Word
+// boundaries are only added next to letters and digits so that words such as
+// "C++" can still be matched.
+func wordRegexp(word string) string {
+	expr := regexp.QuoteMeta(word)
+	if first, _ := utf8.DecodeRuneInString(word); isWordRune(first) {
+		expr = `\b` + expr
+	}
+	if last, _ := utf8.DecodeLastRuneInString(word); isWordRune(last) {
+		expr += `\b`
+	}
+	return expr
+}
+
+// isWordRune returns true if a rune is part of a word.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// locationsSetting converts the value of a "locations" setting to a list of
+// valid locations.
+func locationsSetting(inter interface{}) ([]string, error) {
+	locations, err := stringsSetting("locations", inter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, loc := range locations {
+		switch loc {
+		case locSubject, locBody, locTrailers:
+		default:
+			return nil, fmt.Errorf(`the locations must be "subject", "body" ` +
+				`or "trailers"`)
+		}
+	}
+	return locations, nil
+}
+
+func (rule *bannedWords) Check(subject string, body string) []Violation {
+	if len(rule.entries) == 0 {
+		return nil
+	}
+
+	offset := len(subject) + 2
+	trailerStart := len(body)
+	if trailers := parseTrailers(body); len(trailers) > 0 {
+		trailerStart = trailers[0].pos
+	}
+	regions := map[string]struct {
+		text   string
+		offset int
+	}{
+		locSubject:  {subject, 0},
+		locBody:     {body[:trailerStart], offset},
+		locTrailers: {body[trailerStart:], offset + trailerStart},
+	}
+
+	var violations []Violation
+	for _, entry := range rule.entries {
+		for _, loc := range entry.locations {
+			region := regions[loc]
+			for _, m := range entry.regex.FindAllStringIndex(region.text, -1) {
+				if m[0] == m[1] {
+					continue
+				}
+
+				msg := entry.msg
+				if msg == "" {
+					msg = fmt.Sprintf("\"%s\" isn't allowed.",
+						region.text[m[0]:m[1]])
+				}
+				violations = append(violations, Violation{Rule: rule,
+					Pos: region.offset + m[0], End: region.offset + m[1],
+					Msg: msg})
+			}
+		}
+	}
+	return violations
+}
@@ -1,24 +1,54 @@
 package rules
 
 import (
+	"fmt"
 	"strings"
 )
 
-// BodyLen checks that each line of the body does not exceed 72 characters.
-var BodyLen = &bodyLen{}
+// BodyLen checks that each line of the body does not exceed 72 characters. The
+// "unit" setting controls whether the length is measured in "bytes", "runes" or
+// display "columns" (the default).
+var BodyLen = &bodyLen{
+	DefaultConf: map[string]interface{}{
+		"unit": nil,
+	},
+	unit: unitColumns,
+}
 
-type bodyLen struct{}
+type bodyLen struct {
+	DefaultConf map[string]interface{}
+	unit        string
+}
 
 func (rule *bodyLen) Name() string {
 	return "body-len"
 }
 
 func (rule *bodyLen) Desc() string {
-	return "each line of the body should not exceed 72 characters."
+	return fmt.Sprintf("each line of the body should not exceed 72 %s.",
+		unitName(rule.unit))
+}
+
+func (rule *bodyLen) Doc() Doc {
+	return Doc{
+		Category: CategoryBody,
+		Details: "This rule can be ignored for non-prose (e.g., long URLs, " +
+			"build output, etc.).",
+		Settings: []Setting{unitSettingDoc},
+		Good: []string{"Fix crash when the config file is missing\n\n" +
+			"The config file was opened without checking if it exists.\n" +
+			"Now the default config is used instead."},
+		Bad: []string{"Fix crash when the config file is missing\n\n" +
+			"The config file was opened without checking if it exists. " +
+			"Now the default config is used instead."},
+	}
 }
 
-func (rule *bodyLen) Config(conf map[string]interface{}) error {
-	return nil
+func (rule *bodyLen) Config(conf map[string]interface{}) (err error) {
+	if inter, ok := conf["unit"]; ok {
+		rule.unit, err = unitSetting(inter)
+	}
+	return
 }
 
 func (rule *bodyLen) Check(subject string, body string) []Violation {
@@ -27,8 +57,10 @@ func (rule *bodyLen) Check(subject string, body string) []Violation {
 
 	lines := strings.Split(body, "\n")
 	for _, l := range lines {
-		if len(l) > 72 {
-			violations = append(violations, Violation{rule, offset + 72})
+		if i := exceedsAt(l, 72, rule.unit); i != -1 {
+			violations = append(violations, Violation{Rule: rule,
+				Pos: offset + i, End: offset + len(l),
+				Msg: overLimitMsg("The line", l, 72, rule.unit)})
 		}
 		offset += len(l) + 1
 	}
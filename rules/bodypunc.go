@@ -3,6 +3,7 @@ package rules
 import (
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // BodyPunc checks that the body ends with valid punctuation (".", "!", "?")
@@ -20,6 +21,20 @@ func (rule *bodyPunc) Desc() string {
 		` it ends with a list.`
 }
 
+func (rule *bodyPunc) Doc() Doc {
+	return Doc{
+		Category: CategoryBody,
+		Good: []string{
+			"Fix crash when the config file is missing\n\n" +
+				"The default config is used instead.",
+			"Fix crash when the config file is missing\n\n" +
+				"* Check that the file exists\n* Use the default config",
+		},
+		Bad: []string{"Fix crash when the config file is missing\n\n" +
+			"The default config is used instead"},
+	}
+}
+
 func (rule *bodyPunc) Config(conf map[string]interface{}) error {
 	return nil
 }
@@ -33,7 +48,7 @@ func (rule *bodyPunc) Check(subject string, body string) []Violation {
 	lastLine := strings.TrimSpace(lines[len(lines)-1])
 	if !inList(lastLine) {
 		if !endsWithPunc(lastLine) {
-			return []Violation{Violation{rule, len(subject) + len(body) + 2}}
+			return []Violation{Violation{Rule: rule, Pos: len(subject) + len(body) + 2}}
 		}
 	}
 
@@ -47,6 +62,6 @@ func inList(s string) bool {
 
 // endsWithPunc returns true if a string ends with punctuation.
 func endsWithPunc(s string) bool {
-	last := rune(s[len(s)-1])
+	last, _ := utf8.DecodeLastRuneInString(s)
 	return unicode.IsPunct(last)
 }
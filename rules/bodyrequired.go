@@ -0,0 +1,157 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BodyRequired checks that large or risky commits have a body. The commit
+// needs a body if it changes more lines than the "lines" setting, more files
+// than the "files" setting, any file matching the "paths" globs or if its
+// Conventional Commits type is listed in the "types" setting.
+var BodyRequired = &bodyRequired{
+	DefaultConf: map[string]interface{}{
+		"lines": nil,
+		"files": nil,
+		"paths": nil,
+		"types": nil,
+	},
+}
+
+type bodyRequired struct {
+	DefaultConf map[string]interface{}
+	lines       int
+	files       int
+	paths       []string
+	types       []string
+}
+
+func (rule *bodyRequired) Name() string {
+	return "body-required"
+}
+
+func (rule *bodyRequired) Desc() string {
+	return "large or risky commits must have a body that explains them."
+}
+
+func (rule *bodyRequired) Doc() Doc {
+	return Doc{
+		Category: CategoryBody,
+		Details: "A commit is large or risky if it meets any of the " +
+			"configured conditions. This rule is skipped unless at least one " +
+			"condition is configured.",
+		Settings: []Setting{
+			{
+				Name: "lines",
+				Desc: "require a body when the commit adds and removes more " +
+					"than this many lines in total.",
+			},
+			{
+				Name: "files",
+				Desc: "require a body when the commit changes more than this " +
+					"many files.",
+			},
+			{
+				Name: "paths",
+				Desc: "a list of path globs. Require a body when the commit " +
+					"changes a file that matches one of them.",
+			},
+			{
+				Name: "types",
+				Desc: "a list of Conventional Commits types, e.g., " +
+					"[\"feat\", \"refactor\"]. Require a body when the " +
+					"subject has one of these types.",
+			},
+		},
+		Good: []string{
+			"feat: Add refunds\n\nCustomers can now be refunded from the " +
+				"order page.",
+			"fix: Correct the refund total",
+		},
+		Bad: []string{"feat: Add refunds"},
+		ExampleConf: map[string]interface{}{
+			"types": []interface{}{"feat", "refactor"},
+		},
+	}
+}
+
+func (rule *bodyRequired) Config(conf map[string]interface{}) (err error) {
+	if inter, ok := conf["lines"]; ok {
+		rule.lines = 0
+		if inter != nil {
+			rule.lines, err = intSetting("lines", inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if inter, ok := conf["files"]; ok {
+		rule.files = 0
+		if inter != nil {
+			rule.files, err = intSetting("files", inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if inter, ok := conf["paths"]; ok {
+		rule.paths = nil
+		if inter != nil {
+			rule.paths, err = stringsSetting("paths", inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if inter, ok := conf["types"]; ok {
+		rule.types = nil
+		if inter != nil {
+			rule.types, err = stringsSetting("types", inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (rule *bodyRequired) Check(subject string, body string) []Violation {
+	if strings.TrimSpace(body) != "" {
+		return nil
+	}
+
+	if reason := rule.reason(subject); reason != "" {
+		return []Violation{Violation{Rule: rule, Pos: len(subject),
+			Msg: reason}}
+	}
+	return nil
+}
+
+// reason returns a message that explains why a commit needs a body, or an
+// empty string if it doesn't need one.
+func (rule *bodyRequired) reason(subject string) string {
+	if rule.lines > 0 && Env.Lines > rule.lines {
+		return fmt.Sprintf("The commit changes %d lines, more than the "+
+			"limit of %d.", Env.Lines, rule.lines)
+	}
+
+	if rule.files > 0 && len(Env.Files) > rule.files {
+		return fmt.Sprintf("The commit changes %d files, more than the "+
+			"limit of %d.", len(Env.Files), rule.files)
+	}
+
+	for _, file := range Env.Files {
+		if matchAnyPattern(rule.paths, file) {
+			return fmt.Sprintf("The commit changes %s.", file)
+		}
+	}
+
+	if c, ok := parseConventional(subject); ok && contains(rule.types, c.typ) {
+		return fmt.Sprintf("The commit has the type \"%s\".", c.typ)
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// breakingFooterRegexp matches a line that starts a breaking change footer.
+var breakingFooterRegexp = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:`)
+
+// BreakingChange checks that the breaking change markers of a Conventional
+// Commits message are consistent. A "!" after the type requires a "BREAKING
+// CHANGE:" footer, and the footer must have a description and be part of the
+// trailer block at the end of the body. If the "require-marker" setting is
+// true, then a footer also requires a "!" in the subject. This rule is disabled
+// by default since Conventional Commits allows a "!" without a footer, so it
+// must be enabled in the conf file.
+var BreakingChange = &breakingChange{
+	DefaultConf: map[string]interface{}{
+		"require-marker": nil,
+	},
+}
+
+type breakingChange struct {
+	DefaultConf   map[string]interface{}
+	requireMarker bool
+}
+
+func (rule *breakingChange) Name() string {
+	return "breaking-change"
+}
+
+func (rule *breakingChange) Desc() string {
+	if rule.requireMarker {
+		return "a \"!\" after the type of the subject and a \"BREAKING " +
+			"CHANGE:\" footer must be used together."
+	}
+	return "a \"!\" after the type of the subject requires a \"BREAKING " +
+		"CHANGE:\" footer."
+}
+
+func (rule *breakingChange) Doc() Doc {
+	return Doc{
+		Category: CategoryGeneral,
+		Details: "Release tools derive version numbers from these markers, so " +
+			"they need to agree with each other. The footer can also be " +
+			"written as \"BREAKING-CHANGE:\". It must describe the change and " +
+			"be in the last paragraph of the body along with any other " +
+			"trailers.",
+		Settings: []Setting{{
+			Name: "require-marker",
+			Desc: "if true, a \"BREAKING CHANGE:\" footer also requires a " +
+				"\"!\" after the type of the subject.",
+		}},
+		Good: []string{
+			"feat(api)!: Remove the v1 endpoints\n\nThe v2 endpoints have " +
+				"been available for a year.\n\nBREAKING CHANGE: Clients must " +
+				"use the v2 endpoints.",
+			"feat(api): Add the v2 endpoints",
+		},
+		Bad: []string{
+			"feat(api)!: Remove the v1 endpoints",
+			"feat(api)!: Remove the v1 endpoints\n\nBREAKING CHANGE:",
+			"feat(api)!: Remove the v1 endpoints\n\nBREAKING CHANGE: Clients " +
+				"must use the v2 endpoints.\n\nThe v2 endpoints have been " +
+				"available for a year.",
+			"feat(api): Remove the v1 endpoints\n\nBREAKING CHANGE: Clients " +
+				"must use the v2 endpoints.",
+		},
+		ExampleConf: map[string]interface{}{
+			"require-marker": true,
+		},
+	}
+}
+
+func (rule *breakingChange) DisabledByDefault() bool {
+	return true
+}
+
+func (rule *breakingChange) Config(conf map[string]interface{}) error {
+	inter, ok := conf["require-marker"]
+	if !ok {
+		return nil
+	}
+
+	rule.requireMarker = false
+	if inter != nil {
+		rule.requireMarker, ok = inter.(bool)
+		if !ok {
+			return fmt.Errorf("the require-marker setting must be true or " +
+				"false")
+		}
+	}
+	return nil
+}
+
+func (rule *breakingChange) Check(subject string, body string) []Violation {
+	offset := len(subject) + 2
+	var violations []Violation
+
+	var footer *trailer
+	trailers := parseTrailers(body)
+	for i, t := range trailers {
+		if t.key == "BREAKING CHANGE" || t.key == "BREAKING-CHANGE" {
+			footer = &trailers[i]
+			break
+		}
+	}
+
+	if footer == nil {
+		if loc := breakingFooterRegexp.FindStringIndex(body); loc != nil {
+			violations = append(violations, Violation{Rule: rule,
+				Pos: offset + loc[0], End: offset + loc[1],
+				Msg: "The footer must be in the last paragraph of the body " +
+					"along with any other trailers."})
+		}
+	} else if strings.TrimSpace(footer.value) == "" {
+		violations = append(violations, Violation{Rule: rule,
+			Pos: offset + footer.pos, End: offset + footer.vpos,
+			Msg: "The footer must describe the breaking change."})
+	}
+
+	c, ok := parseConventional(subject)
+	if !ok {
+		return violations
+	}
+
+	hasFooter := footer != nil || len(violations) > 0
+	if c.breaking && !hasFooter {
+		pos := c.descPos - len("!: ")
+		violations = append(violations, Violation{Rule: rule, Pos: pos,
+			Msg: "The subject is marked as a breaking change, but there " +
+				"isn't a \"BREAKING CHANGE:\" footer."})
+	}
+	if !c.breaking && footer != nil && rule.requireMarker {
+		violations = append(violations, Violation{Rule: rule,
+			Pos: offset + footer.pos, End: offset + footer.vpos,
+			Msg: fmt.Sprintf("The message has a breaking change footer, so "+
+				"the type should be followed by a \"!\", e.g., \"%s!:\".",
+				subject[:c.descPos-len(": ")])})
+	}
+	return violations
+}
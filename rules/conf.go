@@ -0,0 +1,48 @@
+package rules
+
+import (
+	"fmt"
+)
+
+// stringSetting converts the value of a setting to a string. The name of the
+// setting is used to create a human-readable error if the value isn't a string.
+func stringSetting(name string, inter interface{}) (string, error) {
+	s, ok := inter.(string)
+	if !ok {
+		return "", fmt.Errorf("the %s setting must be a string", name)
+	}
+	return s, nil
+}
+
+// stringsSetting converts the value of a setting to a slice of strings. The
+// name of the setting is used to create a human-readable error if the value
+// isn't a list of strings.
+func stringsSetting(name string, inter interface{}) ([]string, error) {
+	list, ok := inter.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the %s setting must be a list of strings",
+			name)
+	}
+
+	strs := make([]string, len(list))
+	for i, elem := range list {
+		s, ok := elem.(string)
+		if !ok {
+			return nil, fmt.Errorf("the %s setting must be a list of strings",
+				name)
+		}
+		strs[i] = s
+	}
+	return strs, nil
+}
+
+// intSetting converts the value of a setting to a non-negative integer. JSON
+// numbers are decoded as float64, so any whole number is accepted. The name of
+// the setting is used to create a human-readable error if the value isn't one.
+func intSetting(name string, inter interface{}) (int, error) {
+	f, ok := inter.(float64)
+	if !ok || f < 0 || f != float64(int(f)) {
+		return 0, fmt.Errorf("the %s setting must be a whole number", name)
+	}
+	return int(f), nil
+}
@@ -0,0 +1,51 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+)
+
+// conventionalPattern matches the header of a Conventional Commits subject,
+// e.g., "feat(api)!: ". The submatches are the type, the scope and the
+// breaking change marker.
+var conventionalPattern = regexp.MustCompile(`^(\w[\w-]*)(?:\(([^()\n]*)\))?(!)?: `)
+
+// conventional is a subject that follows the Conventional Commits format:
+// "type(scope)!: description".
+type conventional struct {
+	typ      string // typ is the type of change, e.g., "feat" or "fix".
+	scope    string // scope is the optional scope, without parentheses.
+	scopePos int    // scopePos is the index of the scope in the subject.
+	breaking bool   // breaking is true if the type is followed by a "!".
+	descPos  int    // descPos is the index of the description in the subject.
+}
+
+// parseConventional parses a subject in the Conventional Commits format. ok is
+// false if the subject doesn't follow the format.
+func parseConventional(subject string) (c conventional, ok bool) {
+	m := conventionalPattern.FindStringSubmatchIndex(subject)
+	if m == nil {
+		return c, false
+	}
+
+	c.typ = subject[m[2]:m[3]]
+	if m[4] != -1 {
+		c.scope = subject[m[4]:m[5]]
+		c.scopePos = m[4]
+	}
+	c.breaking = m[6] != -1
+	c.descPos = m[1]
+	return c, true
+}
+
+// scopes splits a scope that lists several scopes, e.g., "api,ui", into its
+// parts. Spaces around each part are ignored.
+func (c conventional) scopes() []string {
+	var scopes []string
+	for _, s := range strings.Split(c.scope, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
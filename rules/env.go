@@ -0,0 +1,25 @@
+package rules
+
+// Env describes the repository that a commit message is being checked in as
+// well as any details about the message that are lost before it's passed to the
+// rules. commitfmt populates it before any rules are checked so that rules can
+// take more than just the subject and body into account. Rules must handle any
+// field being empty, e.g., when commitfmt isn't run inside of a git repository.
+var Env = &Environment{}
+
+// Environment contains information about a git repository.
+type Environment struct {
+	Branch string // Branch is the short name of the branch being committed to.
+
+	// Files lists the paths of the files changed by the commit, relative to
+	// the root of the repository and separated by "/".
+	Files []string
+
+	// Lines is the number of lines added plus the number of lines removed by
+	// the commit. Changes to binary files aren't counted.
+	Lines int
+
+	// CRLF contains the positions of any line breaks in the message that were
+	// originally CRLF before they were normalized to LF.
+	CRLF []int
+}
@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"path"
+	"strings"
+)
+
+// MatchGlob returns true if a slash-separated path matches a glob pattern. Each
+// segment of the pattern is matched against a segment of the path using the same
+// syntax as path.Match, except for "**", which matches zero or more segments.
+// For example, "api/**/*.go" matches "api/server.go" and "api/v2/server.go".
+func MatchGlob(pattern string, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+// matchSegments matches the segments of a path against the segments of a glob
+// pattern.
+func matchSegments(pattern []string, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := len(name); i >= 0; i-- {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], name[0]); !matched {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// MatchAnyGlob returns true if any of the paths match a glob pattern.
+func MatchAnyGlob(pattern string, names []string) bool {
+	for _, name := range names {
+		if MatchGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAnyPattern returns true if a path matches any of the glob patterns.
+func matchAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if MatchGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,375 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultRefPatterns are the patterns used to find issue references when none
+// have been configured. They match Jira-style keys (e.g., "ABC-123") and
+// GitHub-style numbers (e.g., "#123").
+var defaultRefPatterns = []string{
+	`\b(?P<project>[A-Z][A-Z0-9]+)-[0-9]+\b`,
+	`#[0-9]+\b`,
+}
+
+// defaultBranchPattern is the pattern used to find an issue key in the branch
+// name when none has been configured.
+const defaultBranchPattern = `[A-Z][A-Z0-9]+-[0-9]+`
+
+// defaultRefTrailers are the trailer keys that are searched when the location
+// is "trailers" and no trailers have been configured.
+var defaultRefTrailers = []string{"Refs"}
+
+// IssueRef checks that the commit message references an issue or ticket. This
+// rule is disabled by default and must be enabled in the conf file. It accepts
+// the following settings:
+//
+//	"patterns" - a list of regexes that match an issue reference. A pattern may
+//	contain a subexpression named "project" that captures the reference's
+//	project key.
+//	"projects" - a list of valid project keys. When set, at least one reference
+//	must belong to one of these projects.
+//	"location" - where the reference must appear: "subject", "body", "trailers"
+//	or "any" (the default).
+//	"trailers" - the trailer keys searched when the location is "trailers".
+//	Defaults to "Refs".
+//	"branch" - if true, the issue key found in the current branch name (e.g.,
+//	"PAY-481" in "feature/PAY-481-refunds") must be referenced.
+//	"branch-pattern" - a regex that matches the issue key in the branch name.
+var IssueRef = &issueRef{
+	DefaultConf: map[string]interface{}{
+		"patterns":       nil,
+		"projects":       nil,
+		"location":       nil,
+		"trailers":       nil,
+		"branch":         nil,
+		"branch-pattern": nil,
+	},
+}
+
+func init() {
+	IssueRef.Config(IssueRef.DefaultConf)
+}
+
+type issueRef struct {
+	DefaultConf map[string]interface{}
+	patterns    []*regexp.Regexp
+	projects    []string
+	location    string
+	trailers    []string
+	branch      bool
+	branchRegex *regexp.Regexp
+}
+
+// issueMatch is an issue reference found in the commit message.
+type issueMatch struct {
+	pos     int    // pos is the position of the reference in the message.
+	text    string // text is the reference as it appears in the message.
+	project string // project is the reference's project key, if it has one.
+}
+
+func (rule *issueRef) Name() string {
+	return "issue-ref"
+}
+
+func (rule *issueRef) Desc() string {
+	var where string
+	switch rule.location {
+	case "subject":
+		where = "the subject must reference an issue"
+	case "body":
+		where = "the body must reference an issue"
+	case "trailers":
+		where = fmt.Sprintf(`the body must reference an issue in a "%s" trailer`,
+			strings.Join(rule.trailers, `", "`))
+	default:
+		where = "the commit message must reference an issue"
+	}
+
+	if key := rule.branchKey(Env.Branch); rule.branch && key != "" {
+		return fmt.Sprintf(`%s. The issue "%s" from the branch name must be `+
+			`referenced.`, where, key)
+	}
+	if len(rule.projects) > 0 {
+		return fmt.Sprintf("%s. The issue must belong to one of these "+
+			"projects: %s.", where, strings.Join(rule.projects, ", "))
+	}
+	return where + "."
+}
+
+func (rule *issueRef) Doc() Doc {
+	return Doc{
+		Category: CategoryGeneral,
+		Settings: []Setting{
+			{
+				Name: "patterns",
+				Desc: "a list of regexes that match an issue reference. A " +
+					"pattern may contain a subexpression named \"project\" " +
+					"that captures the reference's project key. Defaults to " +
+					"Jira-style keys (ABC-123) and GitHub-style numbers " +
+					"(#123).",
+			},
+			{
+				Name: "projects",
+				Desc: "a list of valid project keys. When set, at least one " +
+					"reference must belong to one of these projects.",
+			},
+			{
+				Name: "location",
+				Desc: "where the reference must appear: \"subject\", " +
+					"\"body\", \"trailers\" or \"any\" (the default).",
+			},
+			{
+				Name: "trailers",
+				Desc: "the trailer keys that are searched when the location " +
+					"is \"trailers\". Defaults to [\"Refs\"].",
+			},
+			{
+				Name: "branch",
+				Desc: "if true, the issue key found in the current branch " +
+					"name (e.g., PAY-481 in feature/PAY-481-refunds) must be " +
+					"referenced.",
+			},
+			{
+				Name: "branch-pattern",
+				Desc: "a regex that matches the issue key in the branch " +
+					"name. Defaults to Jira-style keys.",
+			},
+		},
+		Good: []string{"Fix crash when the config file is missing\n\n" +
+			"The default config is used instead.\n\nRefs: PAY-481"},
+		Bad: []string{"Fix crash when the config file is missing\n\n" +
+			"The default config is used instead."},
+	}
+}
+
+func (rule *issueRef) DisabledByDefault() bool {
+	return true
+}
+
+func (rule *issueRef) Config(conf map[string]interface{}) (err error) {
+	if inter, ok := conf["patterns"]; ok {
+		strs := defaultRefPatterns
+		if inter != nil {
+			strs, err = stringsSetting("patterns", inter)
+			if err != nil {
+				return
+			}
+		}
+
+		patterns := make([]*regexp.Regexp, len(strs))
+		for i, s := range strs {
+			patterns[i], err = regexp.Compile(s)
+			if err != nil {
+				err = fmt.Errorf("the patterns must be valid regular " +
+					"expressions")
+				return
+			}
+		}
+		rule.patterns = patterns
+	}
+
+	if inter, ok := conf["projects"]; ok {
+		rule.projects = nil
+		if inter != nil {
+			rule.projects, err = stringsSetting("projects", inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if inter, ok := conf["location"]; ok {
+		rule.location = "any"
+		if inter != nil {
+			rule.location, err = stringSetting("location", inter)
+			if err != nil {
+				return
+			}
+		}
+
+		switch rule.location {
+		case "any", "subject", "body", "trailers":
+		default:
+			rule.location = "any"
+			err = fmt.Errorf(`the location must be "subject", "body", ` +
+				`"trailers" or "any"`)
+			return
+		}
+	}
+
+	if inter, ok := conf["trailers"]; ok {
+		rule.trailers = defaultRefTrailers
+		if inter != nil {
+			rule.trailers, err = stringsSetting("trailers", inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if inter, ok := conf["branch"]; ok {
+		rule.branch = false
+		if inter != nil {
+			rule.branch, ok = inter.(bool)
+			if !ok {
+				err = fmt.Errorf("the branch setting must be true or false")
+				return
+			}
+		}
+	}
+
+	if inter, ok := conf["branch-pattern"]; ok {
+		str := defaultBranchPattern
+		if inter != nil {
+			str, err = stringSetting("branch-pattern", inter)
+			if err != nil {
+				return
+			}
+		}
+
+		rule.branchRegex, err = regexp.Compile(str)
+		if err != nil {
+			err = fmt.Errorf("the branch pattern must be a valid regular " +
+				"expression")
+			return
+		}
+	}
+
+	return
+}
+
+// BranchKey returns the issue key found in a branch name using the issue-ref
+// rule's "branch-pattern" setting, or an empty string if the branch name
+// doesn't contain one. It's exported so that commitfmt can fill in the issue
+// key when it inserts a message template.
+func BranchKey(branch string) string {
+	return IssueRef.branchKey(branch)
+}
+
+// branchKey returns the issue key found in a branch name, or an empty string if
+// the branch name doesn't contain one.
+func (rule *issueRef) branchKey(branch string) string {
+	if rule.branchRegex == nil {
+		return ""
+	}
+	return rule.branchRegex.FindString(branch)
+}
+
+func (rule *issueRef) Check(subject string, body string) []Violation {
+	offset := len(subject) + 2
+	var matches []issueMatch
+	missingPos := 0
+
+	switch rule.location {
+	case "subject":
+		matches = rule.find(subject, 0)
+	case "body":
+		matches = rule.find(body, offset)
+		missingPos = offset
+	case "trailers":
+		for _, t := range parseTrailers(body) {
+			if rule.isRefTrailer(t.key) {
+				matches = append(matches, rule.find(t.value, offset+t.vpos)...)
+			}
+		}
+		missingPos = offset + len(body)
+	default:
+		matches = append(rule.find(subject, 0), rule.find(body, offset)...)
+	}
+
+	if len(body) == 0 && missingPos != 0 {
+		missingPos = len(subject)
+	}
+
+	if len(matches) == 0 {
+		return []Violation{Violation{Rule: rule, Pos: missingPos}}
+	}
+
+	if key := rule.branchKey(Env.Branch); rule.branch && key != "" {
+		for _, m := range matches {
+			if m.text == key {
+				return nil
+			}
+		}
+		m := firstMatch(matches)
+		return []Violation{m.violation(rule, fmt.Sprintf(`"%s" doesn't match `+
+			`the issue "%s" from the branch name.`, m.text, key))}
+	}
+
+	if len(rule.projects) == 0 {
+		return nil
+	}
+	for _, m := range matches {
+		if rule.isValidProject(m.project) {
+			return nil
+		}
+	}
+	m := firstMatch(matches)
+	return []Violation{m.violation(rule, fmt.Sprintf(`"%s" doesn't belong to `+
+		`a valid project.`, m.text))}
+}
+
+// firstMatch returns the earliest match in the message.
+func firstMatch(matches []issueMatch) issueMatch {
+	first := matches[0]
+	for _, m := range matches[1:] {
+		if m.pos < first.pos {
+			first = m
+		}
+	}
+	return first
+}
+
+// violation returns a violation of rule that covers the match.
+func (m issueMatch) violation(rule Interface, msg string) Violation {
+	return Violation{Rule: rule, Pos: m.pos, End: m.pos + len(m.text),
+		Msg: msg}
+}
+
+// find returns every issue reference in a string. The offset is added to the
+// position of each match so that it points into the full commit message.
+func (rule *issueRef) find(s string, offset int) []issueMatch {
+	var matches []issueMatch
+	for _, p := range rule.patterns {
+		projectIndex := -1
+		for i, name := range p.SubexpNames() {
+			if name == "project" {
+				projectIndex = i
+			}
+		}
+
+		for _, loc := range p.FindAllStringSubmatchIndex(s, -1) {
+			m := issueMatch{pos: offset + loc[0], text: s[loc[0]:loc[1]]}
+			if projectIndex != -1 && loc[2*projectIndex] != -1 {
+				m.project = s[loc[2*projectIndex]:loc[2*projectIndex+1]]
+			}
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// isRefTrailer returns true if a trailer key is one of the configured issue
+// reference trailers. Trailer keys are case-insensitive.
+func (rule *issueRef) isRefTrailer(key string) bool {
+	for _, t := range rule.trailers {
+		if strings.EqualFold(t, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidProject returns true if a project key is one of the configured
+// projects.
+func (rule *issueRef) isValidProject(project string) bool {
+	for _, p := range rule.projects {
+		if p == project {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"fmt"
+)
+
+// LineEndings checks that lines end with LF instead of CRLF. Setting "fix" to
+// true tells commitfmt to convert CRLF line endings to LF in the message file
+// instead of reporting them.
+var LineEndings = &lineEndings{
+	DefaultConf: map[string]interface{}{
+		"fix": nil,
+	},
+}
+
+type lineEndings struct {
+	DefaultConf map[string]interface{}
+	fix         bool
+}
+
+func (rule *lineEndings) Name() string {
+	return "line-endings"
+}
+
+func (rule *lineEndings) Desc() string {
+	return `lines should end with LF ("\n") instead of CRLF ("\r\n").`
+}
+
+func (rule *lineEndings) Doc() Doc {
+	return Doc{
+		Category: CategoryGeneral,
+		Details: "CRLF line endings are always normalized before the other " +
+			"rules are checked. Since git removes carriage returns when it " +
+			"cleans up a message, this rule only matters when the " +
+			"\"verbatim\" cleanup mode is used.",
+		Settings: []Setting{{
+			Name: "fix",
+			Desc: "if true, CRLF line endings are converted to LF in the " +
+				"message file instead of being reported.",
+		}},
+		Good: []string{"Fix crash when the config file is missing\n\n" +
+			"The default config is used instead."},
+		Bad: []string{"Fix crash when the config file is missing\r\n\r\n" +
+			"The default config is used instead."},
+	}
+}
+
+func (rule *lineEndings) Config(conf map[string]interface{}) error {
+	inter, ok := conf["fix"]
+	if !ok {
+		return nil
+	}
+
+	rule.fix = false
+	if inter != nil {
+		rule.fix, ok = inter.(bool)
+		if !ok {
+			return fmt.Errorf("the fix setting must be true or false")
+		}
+	}
+	return nil
+}
+
+// Fixes returns true if CRLF line endings should be converted to LF instead of
+// being reported.
+func (rule *lineEndings) Fixes() bool {
+	return rule.fix
+}
+
+func (rule *lineEndings) Check(subject string, body string) []Violation {
+	if rule.fix {
+		return nil
+	}
+
+	var violations []Violation
+	for _, pos := range Env.CRLF {
+		violations = append(violations, Violation{Rule: rule, Pos: pos})
+	}
+	return violations
+}
@@ -13,13 +13,21 @@ func (rule *noEmpty) Desc() string {
 	return "the commit message cannot be empty."
 }
 
+func (rule *noEmpty) Doc() Doc {
+	return Doc{
+		Category: CategoryGeneral,
+		Good:     []string{"Fix crash when the config file is missing"},
+		Bad:      []string{""},
+	}
+}
+
 func (rule *noEmpty) Config(conf map[string]interface{}) error {
 	return nil
 }
 
 func (rule *noEmpty) Check(subject string, body string) []Violation {
 	if subject == "" {
-		return []Violation{Violation{rule, 0}}
+		return []Violation{Violation{Rule: rule, Pos: 0}}
 	}
 	return nil
 }
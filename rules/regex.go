@@ -0,0 +1,268 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Parts of a commit message that a regex pattern can target.
+const (
+	targetSubject    = "subject"
+	targetBody       = "body"
+	targetLines      = "lines"
+	targetParagraphs = "paragraphs"
+	targetTrailers   = "trailers"
+)
+
+// Regex checks the message against the named patterns configured via the
+// "patterns" setting. Each pattern targets part of the message and either must
+// match it or must not match it.
+var Regex = &regex{
+	DefaultConf: map[string]interface{}{
+		"patterns": nil,
+	},
+}
+
+type regex struct {
+	DefaultConf map[string]interface{}
+	patterns    []regexPattern
+}
+
+// regexPattern is a single named pattern of the regex rule.
+type regexPattern struct {
+	name        string         // name identifies the pattern in messages.
+	regex       *regexp.Regexp // regex is the compiled pattern.
+	mustMatch   bool           // mustMatch is false if regex must not match.
+	target      string         // target is the part of the message to check.
+	description string         // description optionally replaces the message.
+}
+
+// regexUnit is a part of the message that a pattern is checked against.
+type regexUnit struct {
+	text string // text is the part of the message.
+	pos  int    // pos is the position of text in the message.
+}
+
+func (rule *regex) Name() string {
+	return "regex"
+}
+
+func (rule *regex) Desc() string {
+	return "the message must satisfy the configured patterns."
+}
+
+func (rule *regex) Doc() Doc {
+	return Doc{
+		Category: CategoryGeneral,
+		Details: "Each pattern is a map with either a \"must-match\" or a " +
+			"\"must-not-match\" regex, an optional \"target\" and an " +
+			"optional \"description\" that's shown instead of the default " +
+			"message when the pattern is violated. The target can be " +
+			"\"subject\" (the default), \"body\", \"lines\" (each line of the " +
+			"body), \"paragraphs\" (each paragraph of the body) or " +
+			"\"trailers\" (each trailer). Empty targets are skipped. This rule " +
+			"is skipped unless patterns are configured.",
+		Settings: []Setting{{
+			Name: "patterns",
+			Desc: "a map from the name of each pattern to its settings.",
+		}},
+		Good: []string{
+			"PAY-481 Add refunds\n\nRefunds can be issued from the order " +
+				"page.",
+		},
+		Bad: []string{
+			"Add refunds",
+			"PAY-481 Add refunds\n\nFIXME: Refunds can't be partial yet.",
+		},
+		ExampleConf: map[string]interface{}{
+			"patterns": map[string]interface{}{
+				"ticket": map[string]interface{}{
+					"must-match":  "^[A-Z]+-[0-9]+ ",
+					"description": "Start the subject with a ticket key.",
+				},
+				"no-fixme": map[string]interface{}{
+					"must-not-match": `\bFIXME\b`,
+					"target":         "lines",
+				},
+			},
+		},
+	}
+}
+
+func (rule *regex) Config(conf map[string]interface{}) (err error) {
+	inter, ok := conf["patterns"]
+	if !ok {
+		return
+	}
+
+	rule.patterns = nil
+	if inter == nil {
+		return
+	}
+
+	m, ok := inter.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("the patterns setting must map each name to a " +
+			"pattern")
+	}
+
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var p regexPattern
+		p, err = parseRegexPattern(name, m[name])
+		if err != nil {
+			rule.patterns = nil
+			return
+		}
+		rule.patterns = append(rule.patterns, p)
+	}
+	return
+}
+
+// parseRegexPattern converts the settings of a single named pattern.
+func parseRegexPattern(name string, inter interface{}) (p regexPattern,
+	err error) {
+	p.name = name
+	settings, ok := inter.(map[string]interface{})
+	if !ok {
+		return p, fmt.Errorf("the \"%s\" pattern must be a map of settings",
+			name)
+	}
+
+	expr, hasMatch := settings["must-match"]
+	p.mustMatch = hasMatch
+	if notExpr, ok := settings["must-not-match"]; ok {
+		if hasMatch {
+			return p, fmt.Errorf("the \"%s\" pattern can't have both "+
+				"must-match and must-not-match", name)
+		}
+		expr = notExpr
+	}
+
+	s, ok := expr.(string)
+	if !ok {
+		return p, fmt.Errorf("the \"%s\" pattern must have a must-match or "+
+			"must-not-match regex", name)
+	}
+	p.regex, err = regexp.Compile(s)
+	if err != nil {
+		return p, fmt.Errorf("the \"%s\" pattern must be a valid regular "+
+			"expression", name)
+	}
+
+	p.target = targetSubject
+	if inter, ok := settings["target"]; ok {
+		p.target, _ = inter.(string)
+		switch p.target {
+		case targetSubject, targetBody, targetLines, targetParagraphs,
+			targetTrailers:
+		default:
+			return p, fmt.Errorf(`the target of the "%s" pattern must be `+
+				`"subject", "body", "lines", "paragraphs" or "trailers"`, name)
+		}
+	}
+
+	if inter, ok := settings["description"]; ok {
+		p.description, err = stringSetting("description", inter)
+	}
+	return p, err
+}
+
+func (rule *regex) Check(subject string, body string) []Violation {
+	var violations []Violation
+	for _, p := range rule.patterns {
+		for _, unit := range regexUnits(p.target, subject, body) {
+			violations = append(violations, rule.checkUnit(p, unit)...)
+		}
+	}
+	return violations
+}
+
+// checkUnit checks a single part of the message against a pattern.
+func (rule *regex) checkUnit(p regexPattern, unit regexUnit) []Violation {
+	if p.mustMatch {
+		if p.regex.MatchString(unit.text) {
+			return nil
+		}
+
+		msg := p.description
+		if msg == "" {
+			msg = fmt.Sprintf("The %s doesn't match the \"%s\" pattern.",
+				targetName(p.target), p.name)
+		}
+		return []Violation{Violation{Rule: rule, Pos: unit.pos,
+			End: unit.pos + len(unit.text), Msg: msg}}
+	}
+
+	var violations []Violation
+	for _, m := range p.regex.FindAllStringIndex(unit.text, -1) {
+		msg := p.description
+		if msg == "" {
+			msg = fmt.Sprintf("\"%s\" matches the \"%s\" pattern, which "+
+				"isn't allowed.", unit.text[m[0]:m[1]], p.name)
+		}
+		violations = append(violations, Violation{Rule: rule,
+			Pos: unit.pos + m[0], End: unit.pos + m[1], Msg: msg})
+	}
+	return violations
+}
+
+// targetName describes a target in a message.
+func targetName(target string) string {
+	switch target {
+	case targetLines:
+		return "line"
+	case targetParagraphs:
+		return "paragraph"
+	case targetTrailers:
+		return "trailer"
+	}
+	return target
+}
+
+// regexUnits splits the part of the message that a pattern targets into the
+// units that the pattern is checked against. Empty units are left out.
+func regexUnits(target string, subject string, body string) []regexUnit {
+	offset := len(subject) + 2
+	var units []regexUnit
+	switch target {
+	case targetSubject:
+		units = append(units, regexUnit{subject, 0})
+	case targetBody:
+		units = append(units, regexUnit{body, offset})
+	case targetLines, targetParagraphs:
+		sep := "\n"
+		if target == targetParagraphs {
+			sep = "\n\n"
+		}
+		pos := offset
+		for _, text := range strings.Split(body, sep) {
+			units = append(units, regexUnit{text, pos})
+			pos += len(text) + len(sep)
+		}
+	case targetTrailers:
+		trailers := parseTrailers(body)
+		for i, t := range trailers {
+			end := len(body)
+			if i+1 < len(trailers) {
+				end = trailers[i+1].pos - 1
+			}
+			units = append(units, regexUnit{body[t.pos:end], offset + t.pos})
+		}
+	}
+
+	var nonEmpty []regexUnit
+	for _, unit := range units {
+		if strings.TrimSpace(unit.text) != "" {
+			nonEmpty = append(nonEmpty, unit)
+		}
+	}
+	return nonEmpty
+}
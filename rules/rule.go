@@ -53,7 +53,7 @@ with a certain string.
 		}
 
 		if !strings.HasPrefix(subject, rule.prefix) {
-			return []Violation{Violation{rule, 0}}
+			return []Violation{Violation{Rule: rule, Pos: 0}}
 		}
 		return nil
 	}
@@ -61,6 +61,13 @@ with a certain string.
 As long as your rule is added to rules.All, it will be automatically be picked
 up and checked by commitfmt.
 
+If a violation covers more than one character, set its End so that commitfmt
+can highlight all of them. For example, a rule that checks the length of the
+subject could return Violation{Rule: rule, Pos: 50, End: len(subject)} to
+highlight every character past the limit. Violations can also have a message
+that gives more detail than the rule's description, such as "The subject is 63
+characters, 13 over the limit of 50."
+
 Remember that when calculating the position of a violation, you must take into
 account the subject, two newlines, and the body. So if a violation occurs at
 index 0 in the body, your rule should return the position len(subject) + 2.
@@ -72,6 +79,11 @@ settings. It completely up to each rule to define and document any settings that
 the user can specify. If a rule requires configuration and none is provided,
 then the rule should silently skip itself by returning nil when Check is called.
 
+Every setting listed in a rule's documentation is passed to Config, even when
+the user hasn't configured it. A setting with a nil value should be reset to its
+default so that settings don't carry over when commitfmt checks several
+messages with different confs, such as the commits in a push.
+
 If an error occurs during configuration (for example, if the value of a setting
 is the wrong type), then a human-readable error should be returned. Returning an
 error will immediately abort any checking and the error will be printed to the
@@ -79,6 +91,13 @@ user. Since the error message will be shown to the user, it should follow the
 same formatting conventions as a rule's description - start with a lowercase
 letter, be one to two sentences and end with a period.
 
+Some rules, such as rules that enforce a team's own conventions, shouldn't be
+checked unless the user asks for them. These rules can implement the Optional
+interface so that they're skipped unless the user enables them in the conf file.
+
+Rules should also implement the Documented interface so that users can learn
+about their settings and see examples of messages that follow and violate them.
+
 Sometimes it's a good idea to change the rule's description based on its
 configuration. For example, a rule's default description might be "the subject
 should start with a configured prefix" but after configuration it changes to
@@ -88,11 +107,22 @@ is violated.
 */
 package rules
 
-// Violation points to a position in the commit message where a rule was
-// violated.
+// Violation points to a position, or a span of characters, in the commit
+// message where a rule was violated.
 type Violation struct {
 	Rule Interface // Rule is the rule that was violated.
 	Pos  int       // Pos is the string index of where the violation occurred.
+
+	// End is the string index just past the last character of the violation.
+	// It can be left as zero if the violation only covers the character at
+	// Pos.
+	End int
+
+	// Msg optionally explains this particular violation, e.g., by saying how
+	// far over a limit the message is. It's shown alongside the rule's
+	// description, so it should be a full sentence that starts with an
+	// uppercase letter and ends with a period.
+	Msg string
 }
 
 // Interface defines the methods that all rules must implement.
@@ -117,15 +147,71 @@ type Interface interface {
 	Check(subject string, body string) []Violation
 }
 
+// Documented is implemented by rules that provide documentation beyond their
+// description. The documentation is shown to the user by commitfmt's "explain"
+// and "rules" commands.
+type Documented interface {
+	// Doc returns the rule's documentation.
+	Doc() Doc
+}
+
+// Categories that a rule can belong to. A rule's category is used to group it
+// with similar rules in the documentation.
+const (
+	CategorySubject = "subject" // CategorySubject rules check the subject.
+	CategoryBody    = "body"    // CategoryBody rules check the body.
+	CategoryGeneral = "general" // CategoryGeneral rules check the whole message.
+)
+
+// Doc contains the documentation for a rule.
+type Doc struct {
+	Category string    // Category is one of the Category constants.
+	Details  string    // Details explains the rule beyond its description.
+	Settings []Setting // Settings lists every setting that the rule accepts.
+	Good     []string  // Good contains example messages that follow the rule.
+	Bad      []string  // Bad contains example messages that violate the rule.
+
+	// ExampleConf contains the settings that the examples assume the rule has
+	// been configured with, or nil if they assume the default settings.
+	ExampleConf map[string]interface{}
+
+	// ExampleFiles lists the files that the examples assume were changed by
+	// the commit, for rules that check Env.Files.
+	ExampleFiles []string
+}
+
+// Setting documents a setting that can be used to configure a rule.
+type Setting struct {
+	Name string // Name is the setting's key in the rule's conf.
+	Desc string // Desc describes the setting and its default value.
+}
+
+// Optional is implemented by rules that are disabled unless the user enables
+// them in the conf file.
+type Optional interface {
+	// DisabledByDefault returns true if the rule should only be checked when
+	// the user has enabled it.
+	DisabledByDefault() bool
+}
+
 // All is a slice of every rule in this package.
 var All = []Interface{
 	NoEmpty,
 	SubjLen,
+	SubjMin,
 	SubjOneLine,
 	SubjSentenceCase,
 	SubjNoPeriod,
 	Whitespace,
 	BodyLen,
 	BodyPunc,
+	BodyRequired,
 	SubjRegex,
+	IssueRef,
+	LineEndings,
+	ScopeMatchesPaths,
+	BreakingChange,
+	BannedWords,
+	Spelling,
+	Regex,
 }
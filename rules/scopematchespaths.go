@@ -0,0 +1,187 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ScopeMatchesPaths checks that the scope of a Conventional Commits subject
+// covers every file changed by the commit. The "scopes" setting maps each scope
+// to a list of path globs (see MatchGlob) and the "catch-all" setting lists
+// scopes that cover any file.
+var ScopeMatchesPaths = &scopeMatchesPaths{
+	DefaultConf: map[string]interface{}{
+		"scopes":    nil,
+		"catch-all": nil,
+	},
+}
+
+type scopeMatchesPaths struct {
+	DefaultConf map[string]interface{}
+	scopes      map[string][]string
+	catchAll    []string
+}
+
+func (rule *scopeMatchesPaths) Name() string {
+	return "scope-matches-paths"
+}
+
+func (rule *scopeMatchesPaths) Desc() string {
+	return "the scope of the subject should cover every file that the " +
+		"commit changes."
+}
+
+func (rule *scopeMatchesPaths) Doc() Doc {
+	return Doc{
+		Category: CategorySubject,
+		Details: "This rule checks subjects in the Conventional Commits " +
+			"format, e.g., \"feat(api): Add refunds\". Several scopes can be " +
+			"separated by commas. Subjects without a scope are skipped, as " +
+			"is every subject unless scopes are configured.",
+		Settings: []Setting{
+			{
+				Name: "scopes",
+				Desc: "a map from each scope to a list of path globs that it " +
+					"covers. \"**\" matches any number of directories.",
+			},
+			{
+				Name: "catch-all",
+				Desc: "a list of scopes that cover any file, e.g., " +
+					"[\"repo\", \"deps\"].",
+			},
+		},
+		Good: []string{
+			"feat(ui): Add a refund button",
+			"chore(deps): Upgrade every dependency",
+		},
+		Bad: []string{
+			"feat(core): Add a refund button",
+			"feat(payments): Add a refund button",
+		},
+		ExampleConf: map[string]interface{}{
+			"scopes": map[string]interface{}{
+				"core": []interface{}{"core/**"},
+				"ui":   []interface{}{"ui/**", "assets/*.css"},
+			},
+			"catch-all": []interface{}{"deps"},
+		},
+		ExampleFiles: []string{"ui/refund.js", "assets/refund.css"},
+	}
+}
+
+func (rule *scopeMatchesPaths) Config(conf map[string]interface{}) (err error) {
+	if inter, ok := conf["scopes"]; ok {
+		rule.scopes = nil
+		if inter != nil {
+			rule.scopes, err = globsSetting(inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if inter, ok := conf["catch-all"]; ok {
+		rule.catchAll = nil
+		if inter != nil {
+			rule.catchAll, err = stringsSetting("catch-all", inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// globsSetting converts the value of the "scopes" setting to a map from each
+// scope to its globs. A scope may be given a single glob instead of a list.
+func globsSetting(inter interface{}) (map[string][]string, error) {
+	m, ok := inter.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the scopes setting must map each scope to a " +
+			"list of globs")
+	}
+
+	scopes := make(map[string][]string, len(m))
+	for scope, v := range m {
+		if glob, ok := v.(string); ok {
+			scopes[scope] = []string{glob}
+			continue
+		}
+
+		globs, err := stringsSetting("scopes", v)
+		if err != nil {
+			return nil, fmt.Errorf("the scopes setting must map each scope " +
+				"to a list of globs")
+		}
+		scopes[scope] = globs
+	}
+	return scopes, nil
+}
+
+func (rule *scopeMatchesPaths) Check(subject string, body string) []Violation {
+	if len(rule.scopes) == 0 || len(Env.Files) == 0 {
+		return nil
+	}
+
+	c, ok := parseConventional(subject)
+	if !ok || c.scope == "" {
+		return nil
+	}
+
+	v := Violation{Rule: rule, Pos: c.scopePos, End: c.scopePos + len(c.scope)}
+	var globs []string
+	for _, scope := range c.scopes() {
+		if contains(rule.catchAll, scope) {
+			return nil
+		}
+
+		scopeGlobs, ok := rule.scopes[scope]
+		if !ok {
+			v.Msg = fmt.Sprintf("The scope \"%s\" isn't one of the "+
+				"configured scopes: %s.", scope, rule.scopeNames())
+			return []Violation{v}
+		}
+		globs = append(globs, scopeGlobs...)
+	}
+
+	var uncovered []string
+	for _, file := range Env.Files {
+		if !matchAnyPattern(globs, file) {
+			uncovered = append(uncovered, file)
+		}
+	}
+
+	switch len(uncovered) {
+	case 0:
+		return nil
+	case 1:
+		v.Msg = fmt.Sprintf("The scope \"%s\" doesn't cover %s.", c.scope,
+			uncovered[0])
+	default:
+		v.Msg = fmt.Sprintf("The scope \"%s\" doesn't cover %s and %d other "+
+			"files.", c.scope, uncovered[0], len(uncovered)-1)
+	}
+	return []Violation{v}
+}
+
+// scopeNames returns a sorted, comma-separated list of every configured scope.
+func (rule *scopeMatchesPaths) scopeNames() string {
+	names := append([]string(nil), rule.catchAll...)
+	for scope := range rule.scopes {
+		names = append(names, scope)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// contains returns true if a slice of strings contains s.
+func contains(strs []string, s string) bool {
+	for _, str := range strs {
+		if str == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,325 @@
+package rules
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultDictionaries are the dictionary files that the spelling rule reads if
+// they exist. Paths are relative to the root of the repository.
+var defaultDictionaries = []string{".commitfmt-words"}
+
+// codeRegexp matches spans of a message that are code and shouldn't be spell
+// checked: fenced code blocks, backticked spans and indented lines.
+var codeRegexp = regexp.MustCompile(
+	"(?s:```.*?```)|`[^`\n]*`|(?m:^(?:    |\t)[^\n]*)")
+
+// hexRegexp matches tokens that look like hashes, e.g., abbreviated commit
+// SHAs.
+var hexRegexp = regexp.MustCompile(`^[0-9a-fA-F]{7,}$`)
+
+// tokenRegexp matches a run of characters between whitespace.
+var tokenRegexp = regexp.MustCompile(`\S+`)
+
+// Prefixes and suffixes that are removed from a word that isn't in the
+// dictionary to find a word that is.
+var (
+	spellingPrefixes = []string{"auto", "co", "de", "dis", "in", "mis", "multi",
+		"non", "over", "pre", "re", "sub", "un", "under"}
+	spellingSuffixes = []struct{ suffix, replacement string }{
+		{"ies", "y"}, {"ied", "y"}, {"ier", "y"}, {"iest", "y"}, {"ily", "y"},
+		{"s", ""}, {"es", ""}, {"ed", ""}, {"ed", "e"}, {"ing", ""},
+		{"ing", "e"}, {"er", ""}, {"er", "e"}, {"est", ""}, {"est", "e"},
+		{"ly", ""}, {"ness", ""}, {"ment", ""}, {"able", ""}, {"able", "e"},
+	}
+)
+
+var (
+	englishOnce sync.Once
+	english     map[string]bool
+)
+
+// Spelling checks the words in the subject and body against a bundled English
+// word list and the words in the dictionary files listed in the "dictionaries"
+// setting and the "words" setting. Tokens that look like code, such as
+// identifiers, paths, URLs and hashes, and anything in backticks are skipped.
+var Spelling = &spelling{
+	DefaultConf: map[string]interface{}{
+		"dictionaries": nil,
+		"words":        nil,
+	},
+}
+
+type spelling struct {
+	DefaultConf map[string]interface{}
+	dictFiles   map[string]bool
+	words       map[string]bool
+}
+
+func (rule *spelling) Name() string {
+	return "spelling"
+}
+
+func (rule *spelling) Desc() string {
+	return "words should be spelled correctly."
+}
+
+func (rule *spelling) Doc() Doc {
+	return Doc{
+		Category: CategoryGeneral,
+		Details: "The bundled word list only contains common words, so " +
+			"project names and jargon should be added to a dictionary. " +
+			"Dictionary files list one word per line and lines starting with " +
+			"\"#\" are ignored. Words that look like code, such as camelCase " +
+			"or snake_case identifiers, paths, URLs, hashes, acronyms and " +
+			"anything in backticks, as well as capitalized words in the middle " +
+			"of a sentence, aren't checked. The trailers aren't checked " +
+			"either. Consider setting \"severity\" to \"warning\" so that " +
+			"misspellings don't block commits.",
+		Settings: []Setting{
+			{
+				Name: "dictionaries",
+				Desc: "a list of files with extra words to allow, relative to " +
+					"the root of the repo. Defaults to [\".commitfmt-words\"], " +
+					"which is ignored if it doesn't exist.",
+			},
+			{
+				Name: "words",
+				Desc: "a list of extra words to allow.",
+			},
+		},
+		Good: []string{
+			"Fix the crash when the config file is missing\n\nThe " +
+				"`loadConf` function now falls back to the defaults. See " +
+				"https://example.com/issues/12 for details.",
+		},
+		Bad: []string{"Fix the crash when the confg file is mising"},
+	}
+}
+
+func (rule *spelling) DisabledByDefault() bool {
+	return true
+}
+
+func (rule *spelling) Config(conf map[string]interface{}) (err error) {
+	if inter, ok := conf["dictionaries"]; ok {
+		paths, optional := defaultDictionaries, true
+		if inter != nil {
+			paths, err = stringsSetting("dictionaries", inter)
+			if err != nil {
+				return
+			}
+			optional = false
+		}
+
+		rule.dictFiles = map[string]bool{}
+		for _, path := range paths {
+			err = readDictionary(path, rule.dictFiles)
+			if err != nil && !(optional && os.IsNotExist(err)) {
+				return fmt.Errorf("the dictionary \"%s\" couldn't be read",
+					path)
+			}
+			err = nil
+		}
+	}
+
+	if inter, ok := conf["words"]; ok {
+		rule.words = map[string]bool{}
+		if inter != nil {
+			var words []string
+			words, err = stringsSetting("words", inter)
+			if err != nil {
+				return
+			}
+			for _, word := range words {
+				rule.words[strings.ToLower(word)] = true
+			}
+		}
+	}
+
+	return
+}
+
+// readDictionary adds the words in a dictionary file to dict.
+func readDictionary(path string, dict map[string]bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			dict[strings.ToLower(line)] = true
+		}
+	}
+	return scanner.Err()
+}
+
+func (rule *spelling) Check(subject string, body string) []Violation {
+	if trailers := parseTrailers(body); len(trailers) > 0 {
+		body = body[:trailers[0].pos]
+	}
+
+	violations := rule.checkText(subject, 0)
+	return append(violations, rule.checkText(body, len(subject)+2)...)
+}
+
+// checkText checks every word in text. offset is the position of text within
+// the message.
+func (rule *spelling) checkText(text string, offset int) []Violation {
+	masked := codeRegexp.ReplaceAllStringFunc(text, func(code string) string {
+		return strings.Repeat(" ", len(code))
+	})
+
+	var violations []Violation
+	sentenceStart := true
+	for _, loc := range tokenRegexp.FindAllStringIndex(masked, -1) {
+		token := masked[loc[0]:loc[1]]
+		start := loc[0] + len(token) - len(strings.TrimLeft(token, `"'([{<`))
+		word := strings.TrimRight(masked[start:loc[1]], `"'.,;:!?)]}>`)
+
+		if !looksLikeCode(word) {
+			for _, part := range splitHyphens(word, start) {
+				if rule.misspelled(part.word, sentenceStart) {
+					violations = append(violations, Violation{Rule: rule,
+						Pos: offset + part.pos,
+						End: offset + part.pos + len(part.word),
+						Msg: fmt.Sprintf("\"%s\" isn't in the dictionary.",
+							part.word)})
+				}
+			}
+		}
+		sentenceStart = strings.ContainsAny(token[len(token)-1:], ".!?:")
+	}
+	return violations
+}
+
+// wordPart is a word, or part of a hyphenated word, and its position.
+type wordPart struct {
+	word string
+	pos  int
+}
+
+// splitHyphens splits a hyphenated word into its parts. pos is the position of
+// the word.
+func splitHyphens(word string, pos int) []wordPart {
+	var parts []wordPart
+	for _, part := range strings.Split(word, "-") {
+		if part != "" {
+			parts = append(parts, wordPart{part, pos})
+		}
+		pos += len(part) + 1
+	}
+	return parts
+}
+
+// looksLikeCode returns true if a token looks like code or a name rather than a
+// word, e.g., an identifier, a path, a URL, a hash or an acronym.
+func looksLikeCode(token string) bool {
+	if token == "" || hexRegexp.MatchString(token) {
+		return true
+	}
+
+	upper, lower := 0, 0
+	var prev rune
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			if unicode.IsLower(prev) {
+				return true
+			}
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		case r != '-' && r != '\'' && r != '’':
+			return true
+		}
+		prev = r
+	}
+	return lower == 0 && upper > 1
+}
+
+// misspelled returns true if a word isn't in any of the dictionaries.
+// Capitalized words are assumed to be names unless they start a sentence.
+func (rule *spelling) misspelled(word string, sentenceStart bool) bool {
+	word = strings.Replace(word, "’", "'", -1)
+	word = strings.TrimSuffix(word, "'s")
+	word = strings.Trim(word, "'")
+	if utf8.RuneCountInString(word) < 2 {
+		return false
+	}
+
+	lower := strings.ToLower(word)
+	if rule.known(lower) {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(word)
+	return sentenceStart || !unicode.IsUpper(first)
+}
+
+// known returns true if a lowercase word, or the word with a common prefix or
+// suffix removed, is in one of the dictionaries.
+func (rule *spelling) known(word string) bool {
+	if rule.knownStem(word, 2) {
+		return true
+	}
+
+	for _, prefix := range spellingPrefixes {
+		if len(word) > len(prefix)+2 && strings.HasPrefix(word, prefix) &&
+			rule.knownStem(word[len(prefix):], 2) {
+			return true
+		}
+	}
+	return false
+}
+
+// knownStem returns true if a word is in one of the dictionaries or if it is
+// after removing up to depth suffixes, e.g., "settings" and "fixed".
+func (rule *spelling) knownStem(word string, depth int) bool {
+	if rule.inDictionary(word) {
+		return true
+	}
+	if depth == 0 {
+		return false
+	}
+
+	for _, s := range spellingSuffixes {
+		if len(word) <= len(s.suffix)+1 || !strings.HasSuffix(word, s.suffix) {
+			continue
+		}
+
+		stem := word[:len(word)-len(s.suffix)]
+		if rule.knownStem(stem+s.replacement, depth-1) {
+			return true
+		}
+
+		// Consonants are often doubled before a suffix, e.g., "stopped".
+		n := len(stem)
+		if s.replacement == "" && n > 2 && stem[n-1] == stem[n-2] &&
+			rule.knownStem(stem[:n-1], depth-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// inDictionary returns true if a lowercase word is in the bundled word list,
+// a dictionary file or the configured words.
+func (rule *spelling) inDictionary(word string) bool {
+	englishOnce.Do(func() {
+		english = map[string]bool{}
+		for _, w := range strings.Fields(englishWords) {
+			english[w] = true
+		}
+	})
+	return english[word] || rule.dictFiles[word] || rule.words[word]
+}
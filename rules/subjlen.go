@@ -1,25 +1,56 @@
 package rules
 
-// SubjLen checks that the subject doesn't exceed 50 characters.
-var SubjLen = &subjLen{}
+import (
+	"fmt"
+)
 
-type subjLen struct{}
+// SubjLen checks that the subject doesn't exceed 50 characters. The "unit"
+// setting controls whether the length is measured in "bytes", "runes" or display
+// "columns" (the default).
+var SubjLen = &subjLen{
+	DefaultConf: map[string]interface{}{
+		"unit": nil,
+	},
+	unit: unitColumns,
+}
+
+type subjLen struct {
+	DefaultConf map[string]interface{}
+	unit        string
+}
 
 func (rule *subjLen) Name() string {
 	return "subj-len"
 }
 
 func (rule *subjLen) Desc() string {
-	return "the subject should not exceed 50 characters."
+	return fmt.Sprintf("the subject should not exceed 50 %s.",
+		unitName(rule.unit))
 }
 
-func (rule *subjLen) Config(conf map[string]interface{}) error {
-	return nil
+func (rule *subjLen) Doc() Doc {
+	return Doc{
+		Category: CategorySubject,
+		Details: "Short subjects are easier to read in tools like " +
+			"git log --oneline.",
+		Settings: []Setting{unitSettingDoc},
+		Good:     []string{"Fix crash when the config file is missing"},
+		Bad: []string{"Fix a crash that happens on startup when the " +
+			"config file is missing"},
+	}
+}
+
+func (rule *subjLen) Config(conf map[string]interface{}) (err error) {
+	if inter, ok := conf["unit"]; ok {
+		rule.unit, err = unitSetting(inter)
+	}
+	return
 }
 
 func (rule *subjLen) Check(subject string, body string) []Violation {
-	if len(subject) > 50 {
-		return []Violation{Violation{rule, 50}}
+	if i := exceedsAt(subject, 50, rule.unit); i != -1 {
+		return []Violation{Violation{Rule: rule, Pos: i, End: len(subject),
+			Msg: overLimitMsg("The subject", subject, 50, rule.unit)}}
 	}
 	return nil
 }
@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultRejectedSubjects are subjects that don't say anything about the
+// change.
+var defaultRejectedSubjects = []string{"update", "updates", "fix", "fixes",
+	"change", "changes", "wip", "misc", "cleanup", "tweak", "tweaks", "stuff",
+	"minor", "temp", "test", "commit"}
+
+// SubjMin checks that the subject is long enough to describe the change. The
+// "min-chars" and "min-words" settings control the minimum length and the
+// "rejected" setting lists subjects that aren't descriptive enough. This rule
+// is disabled by default so that subjects that used to pass aren't rejected
+// after upgrading, so it must be enabled in the conf file.
+var SubjMin = &subjMin{
+	DefaultConf: map[string]interface{}{
+		"min-chars": nil,
+		"min-words": nil,
+		"rejected":  nil,
+	},
+	minChars: 5,
+	minWords: 1,
+	rejected: defaultRejectedSubjects,
+}
+
+type subjMin struct {
+	DefaultConf map[string]interface{}
+	minChars    int
+	minWords    int
+	rejected    []string
+}
+
+func (rule *subjMin) Name() string {
+	return "subj-min"
+}
+
+func (rule *subjMin) Desc() string {
+	if rule.minWords > 1 {
+		return fmt.Sprintf("the subject should be at least %d characters "+
+			"and %d words long and describe the change.", rule.minChars,
+			rule.minWords)
+	}
+	return fmt.Sprintf("the subject should be at least %d characters long "+
+		"and describe the change.", rule.minChars)
+}
+
+func (rule *subjMin) Doc() Doc {
+	return Doc{
+		Category: CategorySubject,
+		Details: "Rejected subjects are compared without regard to case or " +
+			"trailing punctuation.",
+		Settings: []Setting{
+			{
+				Name: "min-chars",
+				Desc: "the minimum number of characters. Defaults to 5.",
+			},
+			{
+				Name: "min-words",
+				Desc: "the minimum number of words. Defaults to 1.",
+			},
+			{
+				Name: "rejected",
+				Desc: "a list of subjects that aren't allowed. Defaults to " +
+					"common low-information subjects such as \"Update\", " +
+					"\"Fix\", \"Changes\" and \"WIP\".",
+			},
+		},
+		Good: []string{"Fix crash when the config file is missing"},
+		Bad:  []string{"x", "Update", "Fixes."},
+	}
+}
+
+func (rule *subjMin) DisabledByDefault() bool {
+	return true
+}
+
+func (rule *subjMin) Config(conf map[string]interface{}) (err error) {
+	if inter, ok := conf["min-chars"]; ok {
+		rule.minChars = 5
+		if inter != nil {
+			rule.minChars, err = intSetting("min-chars", inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if inter, ok := conf["min-words"]; ok {
+		rule.minWords = 1
+		if inter != nil {
+			rule.minWords, err = intSetting("min-words", inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if inter, ok := conf["rejected"]; ok {
+		rule.rejected = defaultRejectedSubjects
+		if inter != nil {
+			rule.rejected, err = stringsSetting("rejected", inter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (rule *subjMin) Check(subject string, body string) []Violation {
+	if subject == "" {
+		return nil
+	}
+
+	v := Violation{Rule: rule, Pos: 0, End: len(subject)}
+	trimmed := strings.TrimRight(subject, ".!?")
+	for _, rejected := range rule.rejected {
+		if strings.EqualFold(trimmed, rejected) {
+			v.Msg = fmt.Sprintf("\"%s\" doesn't describe the change.", subject)
+			return []Violation{v}
+		}
+	}
+
+	if chars := utf8.RuneCountInString(subject); chars < rule.minChars {
+		v.Msg = fmt.Sprintf("The subject is %d characters, %d under the "+
+			"minimum of %d.", chars, rule.minChars-chars, rule.minChars)
+		return []Violation{v}
+	}
+
+	if words := len(strings.Fields(subject)); words < rule.minWords {
+		v.Msg = fmt.Sprintf("The subject is %d words, %d under the minimum "+
+			"of %d.", words, rule.minWords-words, rule.minWords)
+		return []Violation{v}
+	}
+	return nil
+}
@@ -17,6 +17,15 @@ func (rule *subjNoPeriod) Desc() string {
 	return "the subject should not end with a period."
 }
 
+func (rule *subjNoPeriod) Doc() Doc {
+	return Doc{
+		Category: CategorySubject,
+		Details:  "Subjects that end with an ellipsis are allowed.",
+		Good:     []string{"Fix crash when the config file is missing"},
+		Bad:      []string{"Fix crash when the config file is missing."},
+	}
+}
+
 func (rule *subjNoPeriod) Config(conf map[string]interface{}) error {
 	return nil
 }
@@ -27,7 +36,7 @@ func (rule *subjNoPeriod) Check(subject string, body string) []Violation {
 	}
 
 	if strings.HasSuffix(subject, ".") {
-		return []Violation{Violation{rule, len(subject) - 1}}
+		return []Violation{Violation{Rule: rule, Pos: len(subject) - 1}}
 	}
 
 	return nil
@@ -18,13 +18,23 @@ func (rule *subjOneLine) Desc() string {
 		"two newlines between the subject and body."
 }
 
+func (rule *subjOneLine) Doc() Doc {
+	return Doc{
+		Category: CategorySubject,
+		Good: []string{"Fix crash when the config file is missing\n\n" +
+			"The default config is used instead."},
+		Bad: []string{"Fix crash when the config file is missing\n" +
+			"The default config is used instead."},
+	}
+}
+
 func (rule *subjOneLine) Config(conf map[string]interface{}) error {
 	return nil
 }
 
 func (rule *subjOneLine) Check(subject string, body string) []Violation {
 	if index := strings.Index(subject, "\n"); index != -1 {
-		return []Violation{Violation{rule, index}}
+		return []Violation{Violation{Rule: rule, Pos: index}}
 	}
 	return nil
 }
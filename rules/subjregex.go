@@ -30,6 +30,24 @@ func (rule *subjRegex) Desc() string {
 	return "the subject must match a configured regex."
 }
 
+func (rule *subjRegex) Doc() Doc {
+	return Doc{
+		Category: CategorySubject,
+		Details: "This rule is skipped unless a pattern is configured. The " +
+			"regex rule can check other parts of the message against " +
+			"several patterns.",
+		Settings: []Setting{{
+			Name: "pattern",
+			Desc: "the regex that the subject must match.",
+		}},
+		Good: []string{"Ticket: Fix crash when the config file is missing"},
+		Bad:  []string{"Fix crash when the config file is missing"},
+		ExampleConf: map[string]interface{}{
+			"pattern": "^Ticket: ",
+		},
+	}
+}
+
 func (rule *subjRegex) Config(conf map[string]interface{}) (err error) {
 	inter, ok := conf["pattern"]
 	if !ok {
@@ -62,7 +80,7 @@ func (rule *subjRegex) Check(subject string, body string) []Violation {
 	}
 
 	if !rule.pattern.MatchString(subject) {
-		return []Violation{Violation{rule, 0}}
+		return []Violation{Violation{Rule: rule, Pos: 0, End: len(subject)}}
 
 	}
 	return nil
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"fmt"
 	"strings"
 	"unicode"
 )
@@ -23,6 +24,19 @@ func (rule *subjSentenceCase) Desc() string {
 		"first letter of the first word should be capitalized."
 }
 
+func (rule *subjSentenceCase) Doc() Doc {
+	return Doc{
+		Category: CategorySubject,
+		Details: "This rule does its best to detect proper capitalization, " +
+			"but it will need to be ignored for pronouns (e.g., \"Fix " +
+			"references to Java libraries\" will incorrectly trigger this " +
+			"rule). Words with capital letters after the first letter, such " +
+			"as acronyms and class names, are allowed.",
+		Good: []string{"Fix crash in ConfigReader when the file is missing"},
+		Bad:  []string{"Fix Crash When The Config File Is Missing"},
+	}
+}
+
 func (rule *subjSentenceCase) Config(conf map[string]interface{}) error {
 	return nil
 }
@@ -33,20 +47,26 @@ func (rule *subjSentenceCase) Check(subject string, body string) []Violation {
 	}
 
 	var violations []Violation
-	if !unicode.IsUpper(rune(subject[0])) {
-		violations = append(violations, Violation{rule, 0})
+	words := strings.Split(subject, " ")
+	if first := firstRune(subject); !unicode.IsUpper(first) &&
+		!isCaseless(first) {
+		violations = append(violations, Violation{Rule: rule, Pos: 0,
+			End: len(words[0]), Msg: fmt.Sprintf(
+				`The word "%s" should be capitalized.`, words[0])})
 	}
 
-	words := strings.Split(subject, " ")
 	pos := len(words[0]) + 1
 	for _, w := range words[1:] {
 		if len(w) == 0 {
+			pos++
 			continue
 		}
 
-		if unicode.IsUpper(rune(w[0])) {
+		if unicode.IsUpper(firstRune(w)) {
 			if !isException(w) {
-				violations = append(violations, Violation{rule, pos})
+				violations = append(violations, Violation{Rule: rule,
+					Pos: pos, End: pos + len(w), Msg: fmt.Sprintf(
+						`The word "%s" should be lowercase.`, w)})
 			}
 		}
 
@@ -59,11 +79,18 @@ func (rule *subjSentenceCase) Check(subject string, body string) []Violation {
 // isException returns true if a word doesn't violate the rule even though it is
 // capitalized in the middle of a sentence.
 func isException(word string) bool {
-	for _, c := range word[1:] {
-		if unicode.IsUpper(c) {
+	for i, c := range word {
+		if i > 0 && unicode.IsUpper(c) {
 			return true
 		}
 	}
 
 	return false
 }
+
+// isCaseless returns true if a rune is a letter from a script that doesn't have
+// upper and lowercase letters, such as Chinese or Japanese.
+func isCaseless(r rune) bool {
+	return unicode.IsLetter(r) && unicode.ToUpper(r) == r &&
+		unicode.ToLower(r) == r
+}
@@ -0,0 +1,59 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+)
+
+// trailerRegexp matches a single "Key: value" trailer line. "BREAKING CHANGE"
+// is allowed as a key even though it contains a space since it's commonly used
+// by conventional commits.
+var trailerRegexp = regexp.MustCompile(
+	`^([A-Za-z0-9][A-Za-z0-9-]*|BREAKING CHANGE):[ \t]*(.*)$`)
+
+// trailer is a "Key: value" line found at the end of a commit body.
+type trailer struct {
+	key   string // key is the trailer's key, e.g., "Refs".
+	value string // value is everything after the colon, including continuations.
+	pos   int    // pos is the index of the trailer's key within the body.
+	vpos  int    // vpos is the index of the trailer's value within the body.
+}
+
+// parseTrailers returns the trailers found in the last paragraph of a body. The
+// last paragraph is only considered to be a trailer block if every line in it is
+// either a trailer or a continuation of the previous trailer (a line starting
+// with whitespace).
+func parseTrailers(body string) []trailer {
+	start := strings.LastIndex(body, "\n\n")
+	if start == -1 {
+		start = 0
+	} else {
+		start += 2
+	}
+
+	var trailers []trailer
+	pos := start
+	for _, line := range strings.Split(body[start:], "\n") {
+		if line != "" && (line[0] == ' ' || line[0] == '\t') {
+			if len(trailers) == 0 {
+				return nil
+			}
+			last := &trailers[len(trailers)-1]
+			last.value += "\n" + line
+		} else {
+			match := trailerRegexp.FindStringSubmatchIndex(line)
+			if match == nil {
+				return nil
+			}
+			trailers = append(trailers, trailer{
+				key:   line[match[2]:match[3]],
+				value: line[match[4]:match[5]],
+				pos:   pos,
+				vpos:  pos + match[4],
+			})
+		}
+		pos += len(line) + 1
+	}
+
+	return trailers
+}
@@ -1,5 +1,9 @@
 package rules
 
+import (
+	"fmt"
+)
+
 // Whitespace checks that there isn't any unnecessary spacing, i.e., only one
 // line break between paragraphs, only one space between words, and no trailing
 // whitespace.
@@ -17,6 +21,16 @@ func (rule *whitespace) Desc() string {
 		"trailing whitespace."
 }
 
+func (rule *whitespace) Doc() Doc {
+	return Doc{
+		Category: CategoryGeneral,
+		Good: []string{"Fix crash when the config file is missing\n\n" +
+			"The default config is used instead."},
+		Bad: []string{"Fix crash when the  config file is missing\n\n\n" +
+			"The default config is used instead. "},
+	}
+}
+
 func (rule *whitespace) Config(conf map[string]interface{}) error {
 	return nil
 }
@@ -32,17 +46,28 @@ func (rule *whitespace) Check(subject string, body string) []Violation {
 			space++
 			if seenWord {
 				if space > 1 {
-					violations = append(violations, Violation{rule, i})
+					violations = rule.extend(violations, i,
+						"There are %d spaces in a row.")
 				}
 				if msg[i+1] == '\n' || msg[i+1] == '\t' {
-					violations = append(violations, Violation{rule, i + 1})
+					// The whole run of spaces is trailing whitespace, so it
+					// replaces any violation for extra spaces in the run.
+					start := i - space + 1
+					if n := len(violations); n > 0 &&
+						violations[n-1].Pos >= start {
+						violations = violations[:n-1]
+					}
+					violations = append(violations, Violation{Rule: rule,
+						Pos: start, End: i + 1,
+						Msg: "The line ends with whitespace."})
 				}
 			}
 		} else if c == '\n' {
 			newline++
 			seenWord = false
 			if newline > 2 {
-				violations = append(violations, Violation{rule, i})
+				violations = rule.extend(violations, i,
+					"There are %d blank lines in a row.")
 			}
 		} else {
 			space = 0
@@ -53,3 +78,23 @@ func (rule *whitespace) Check(subject string, body string) []Violation {
 
 	return violations
 }
+
+// extend adds the character at pos to the last violation if the violation ends
+// right before pos. Otherwise, a new violation is added for the character. The
+// violation's message is created from msgFormat and the length of the run of
+// whitespace, which includes the one character that came before the violation.
+func (rule *whitespace) extend(violations []Violation, pos int,
+	msgFormat string) []Violation {
+	n := len(violations)
+	if n > 0 && violations[n-1].End == pos {
+		violations[n-1].End = pos + 1
+	} else {
+		violations = append(violations, Violation{Rule: rule, Pos: pos,
+			End: pos + 1})
+		n++
+	}
+
+	v := &violations[n-1]
+	v.Msg = fmt.Sprintf(msgFormat, v.End-v.Pos+1)
+	return violations
+}
@@ -0,0 +1,155 @@
+package rules
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Units that a length limit can be measured in.
+const (
+	unitBytes   = "bytes"   // unitBytes counts the bytes in a UTF-8 string.
+	unitRunes   = "runes"   // unitRunes counts Unicode code points.
+	unitColumns = "columns" // unitColumns counts terminal display columns.
+)
+
+// unitSettingDoc documents the "unit" setting shared by the length rules.
+var unitSettingDoc = Setting{
+	Name: "unit",
+	Desc: "whether the length is measured in \"bytes\", \"runes\" or " +
+		"display \"columns\" (the default). Display columns count East " +
+		"Asian wide characters and emoji as two columns and combining marks " +
+		"as zero.",
+}
+
+// runeRange is an inclusive range of runes.
+type runeRange struct {
+	lo, hi rune
+}
+
+// wideRanges are the runes that take up two columns when displayed in a
+// terminal. This covers the East Asian Wide and Fullwidth characters as well as
+// emoji that are displayed with emoji presentation by default.
+var wideRanges = []runeRange{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x1F004, 0x1F004},
+	{0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A},
+	{0x1F200, 0x1F202}, {0x1F210, 0x1F23B}, {0x1F240, 0x1F248},
+	{0x1F250, 0x1F251}, {0x1F260, 0x1F265}, {0x1F300, 0x1F64F},
+	{0x1F680, 0x1F6FF}, {0x1F7E0, 0x1F7EB}, {0x1F90C, 0x1F9FF},
+	{0x1FA70, 0x1FAFF}, {0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+// RuneWidth returns the number of columns that a rune takes up when displayed in
+// a terminal. Combining marks and other zero-width characters take up no
+// columns, East Asian wide characters and emoji take up two and everything else
+// takes up one.
+func RuneWidth(r rune) int {
+	if r == 0x200D || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+
+	for _, rr := range wideRanges {
+		if r < rr.lo {
+			break
+		}
+		if r <= rr.hi {
+			return 2
+		}
+	}
+	return 1
+}
+
+// Width returns the number of columns that a string takes up when displayed in
+// a terminal.
+func Width(s string) int {
+	width := 0
+	for _, r := range s {
+		width += RuneWidth(r)
+	}
+	return width
+}
+
+// unitSetting converts the value of a "unit" setting to one of the supported
+// units.
+func unitSetting(inter interface{}) (string, error) {
+	if inter == nil {
+		return unitColumns, nil
+	}
+
+	unit, _ := inter.(string)
+	switch unit {
+	case unitBytes, unitRunes, unitColumns:
+		return unit, nil
+	}
+	return unitColumns, fmt.Errorf(`the unit must be "bytes", "runes" or ` +
+		`"columns"`)
+}
+
+// unitName returns the word used to describe a length measured in unit.
+func unitName(unit string) string {
+	if unit == unitBytes {
+		return "bytes"
+	}
+	return "characters"
+}
+
+// length returns the length of s when it's measured in unit.
+func length(s string, unit string) int {
+	switch unit {
+	case unitBytes:
+		return len(s)
+	case unitRunes:
+		return utf8.RuneCountInString(s)
+	}
+	return Width(s)
+}
+
+// overLimitMsg returns a message that explains how far over a limit something
+// is.
+func overLimitMsg(what string, s string, limit int, unit string) string {
+	l := length(s, unit)
+	return fmt.Sprintf("%s is %d %s, %d over the limit of %d.", what, l,
+		unitName(unit), l-limit, limit)
+}
+
+// exceedsAt returns the index of the first character in s that goes past limit
+// when s is measured in unit, or -1 if s doesn't exceed the limit.
+func exceedsAt(s string, limit int, unit string) int {
+	if unit == unitBytes {
+		if len(s) > limit {
+			return limit
+		}
+		return -1
+	}
+
+	length := 0
+	for i, r := range s {
+		if unit == unitRunes {
+			length++
+		} else {
+			length += RuneWidth(r)
+		}
+
+		if length > limit {
+			return i
+		}
+	}
+	return -1
+}
+
+// firstRune returns the first rune in a string.
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
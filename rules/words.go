@@ -0,0 +1,431 @@
+package rules
+
+// englishWords is the word list that the spelling rule checks words against. It
+// contains common English words along with the vocabulary that's often used in
+// commit messages. Only base forms need to be listed since the spelling rule
+// also recognizes regular inflections, e.g., "fixes", "fixed" and "fixing".
+const englishWords = `
+a able abort about above absent absolute abstract accent accept access
+accessible accident accidental accidentally accommodate accompany accomplish
+according account accumulate accurate achieve acid acknowledge acquire acre
+across act action actionable activate active activity actor actual actually
+adapt adapter add addition additional additive address adequate adjacent adjust
+admin administer administrator admittedly adopt adult advance advantage advert
+advice advise affair affect afford affordable afraid after afternoon afterward
+afterwards again against age agency agent aggregate aggressive agnostic ago
+agree agreement ahead aid aim air airport alarm albeit album alcohol alert
+algorithm alias align alike alive all allocate allow allowlist ally almost
+alone along alongside alphabetical alphabetically already alright also alter
+alternate alternative although always am amazing ambiguity ambiguous amend
+amendment among amongst amount an analyse analyses analysis analytics analyze
+ancestor anchor ancient and anew anger angle angry animal ankle annotate
+annotation announce annoy annual anomaly anonymous another answer anti
+anticipate anxious any anybody anyhow anymore anyone anything anyway anywhere
+apart apartment api apologize app apparent apparently appear append appendices
+appendix apple applicable application apply appoint approach appropriate
+approve approximate approximately april arbitrary architecture archive archived
+are area aren't argue argument arise arisen arithmetic arm army arose around
+arrange array arrival arrive arrow art article artifact artificial artist as
+ascending ascii ashamed aside ask asleep aspect assert assertion assess asset
+assign assignment assist associate assume assumption async asynchronous at ate
+atmosphere atomic attach attachment attack attempt attend attention attitude
+attract attractive attribute audience audio audit august aunt authenticate
+authentication author authoritative authority authorization authorize auto
+autocomplete automate automatic automatically automation autumn availability
+available average avoid await awake award aware away awful awkward awoke baby
+back backend background backoff backport backup backward backwards bad badge
+bag bake balance ball ban band bandwidth bank bar bare barely base baseline
+bases basic basically basis batch bath battery battle be beach bean bear beard
+beat beaten beautiful beauty became because become bed bedroom beef been beer
+before beforehand beg began begin beginning begun behalf behave behavior
+behaviour behind being belief believe bell belong below belt bench benchmark
+bend beneath beneficial benefit bent beside besides bespoke best bet beta
+better between beyond bicycle bidirectional big bike bill billion binary bind
+binding bird birth birthday bit bite bitmask bitten bitter black blade blame
+blank blew blind blob block blocker blocking blocklist blog blood blow blown
+blue board boat body bogus bold bone bonus book bookmark boolean boot bootstrap
+border bore boring born borne borrow boss both bother bottle bottleneck bottom
+bought bound boundary bowl box boy brace bracket brain branch brave bread break
+breakage breakfast breakpoint breath breathe bred brick bridge brief briefly
+bright brilliant bring brittle broad broadcast broke broken brother brought
+brown browser brush brute bucket budget buffer bug build builder built bulk
+bullet bump bundle burden burn burnt bus bush business busy but butter button
+buy buyer by bypass byproduct byte cabinet cable cache cake calculate
+calculation calendar call callback caller came camera camp can can't cancel
+cancellation cancer candidate cannot canonical cap capability capable capacity
+capital capitalization capitalize captain capture car card care career careful
+carefully careless caret carousel carry cascade case cast cat catalog
+catastrophic catch category cattle caught cause cautious caveat ceiling
+celebrate cell cent center central century ceremony certain certainly
+certificate chain chair chairman challenge champion chance change changelog
+changeset channel chapter char character charge charity charm charset chart
+cheap cheaper check checkbox checker checklist checkout checkpoint checksum
+cheek cheese chef chemical cherry chest chicken chief child childhood children
+chip chocolate choice choose chore chose chosen chronological chunk church
+cigarette cinema circle circular circumstance cite citizen city civil claim
+clarify clarity class classic classify classroom clean cleaner cleanup clear
+clearly clerk clever cli click clickable client climate climb clinic clipboard
+clock clone close closure clothes clothing cloud club clue clumsy cluster co
+coach coal coalesce coast coat code codebase codename coerce coffee cohesive
+coin coincidence cold collaborate collaborator collapse collar colleague
+collect collection college collide collision colon color colorful colour column
+combination combine come comedy comfort comfortable comma command comment
+commentary commented commercial commission commit committee committer commodity
+common commonly communicate community compact company comparable comparator
+compare comparison compat compatibility compatible compelling competition
+competitor compilation compile compiler complain complaint complement complete
+completely complex complexity compliance compliant complicate component compose
+composite compound comprehensive compress compressed compute computer concat
+concatenate concept concern concert concise conclude concrete concurrency
+concurrent condition conditional conditionally conference confidence confident
+confidential config configuration configure confirm confirmation conflict
+conform confuse confusing confusion congestion congress conjunction connect
+connection conscious consent consequence consequently conservative consider
+considerable considerably consist consistency consistent console consolidate
+const constant constantly constrain constraint construct constructor
+consultation consume consumer contain container content contention contest
+context contextual contiguous continue continuous contract contradict
+contradictory contrary contrast contribute contributing contributor control
+controller controversial convenience convenient convention conventional
+conversation conversely conversion convert cook cookie cool cooperate
+coordinate copy copyright core corn corner corporate correct correction
+correctly correspond corrupt corruption cosmetic cost costly cottage cotton
+cough could couldn't council count counter counterpart country county couple
+courage course court courtesy cousin cover coverage cow crack craft crash
+crashing crazy cream create creation creative creature credential credentials
+credit crew crime criminal crisis criteria critic critical cron crop cross
+crowd crown crucial cruel cry cryptic crypto cryptographic css culture
+cumbersome cup cure curious curly current currently cursor curtain custom
+customer customizable customize cut cycle dad daemon daily damage dance danger
+dangerous dangling dare dark dash dashboard data database date datum daughter
+day dead deadline deadlock deaf deal dealt dear death debate debounce debt
+debug decade december decent decide decimal decision deck declarative declare
+decline decode decompress decorate decorative decorator decouple decrease
+decrement dedicated dedupe deduplicate deep deeply deer default defeat defend
+defensive defer deferred deficiency deficit define definitely definition
+degradation degrade degree delay delegate delete deletion deliberate
+deliberately delightful delimiter deliver delta demand demanding demo
+demonstrate dense dentist deny denylist department departure depend dependency
+dependent deploy deployment deposit deprecate deprecated deprecation depressed
+depth dereference derive descending describe description descriptive
+deserialize desert deserve design desired desk desktop despite dessert
+destination destroy destructive destructor detach detail detailed detect
+detection determine determinism deterministic develop developer development
+device diagnose diagnosis diagnostic diagram dialog dialogue diamond dictionary
+did didn't die diet diff differ difference different differently difficult dig
+digest digit dimension dinner direct direction directive directly directory
+dirt dirty disable disabled disallow disaster discard disconnect discover
+discoverable discrepancy discuss discussion disease dish disjoint disk dismiss
+dispatch dispatcher display disposable dispose disruptive distance distinct
+distinction distinguish distribute distribution district dive divergence
+diverse divide division do doc docker doctor document documentation does
+doesn't dog doing dollar domain dominant don't done door dormant dot double
+doubtless down downgrade download downstream downtime dozen draft drag drastic
+drastically draw drawn dream dress drew drift drink drive driven driver drop
+dropdown drove drug drum dry duck due dug dummy dump duplicate duplicated
+durable duration during dust duty dynamic dynamically each eager eagerly ear
+earlier early earn earth ease easier easily east easy eat eaten echo economy
+edge edit edition editor educate educational effect effective effectively
+efficiency efficient efficiently effort egg eight either elaborate elapsed
+elbow elder elect electric elegant element elephant eleven eligible eliminate
+ellipsis else elsewhere email embed embedded emergency emit emoji emotion
+emphasis emphasize employ employee employer empty emulate emulation enable
+encapsulate encapsulation enclose encode encoding encounter encourage
+encouraging encrypt encryption end endless endpoint enemy energy enforce engage
+engine engineer engineering enhance enhancement enjoy enormous enough ensure
+enter entertain entire entirely entitlement entity entrance entry entrypoint
+enum enumerate env envelope environment ephemeral equal equality equally
+equipment equivalence equivalent ergonomic ergonomics erroneous error escape
+especially essay essential essentially establish estate estimate etc evaluate
+evaluation even evening event eventually ever every everyone everything
+everywhere evict evidence evident evidently evil evolve exact exactly exam
+examine example exceed excellent except exception exceptional excess excessive
+exchange excited exciting exclude exclusion exclusive excuse execute execution
+exercise exhaustive exhibition exist existing exit exotic expand expansion
+expect expectation expensive experience experiment experimental expert
+expiration expire expiry explain explanation explicit explicitly explore
+exponential export expose express expression expressive extend extensibility
+extensible extension extensive extent external externally extra extract
+extraneous extremely eye fabric face facility fact factor factory factual fail
+failure faint fair fairly faith faithful fall fallback fallen fallible false
+familiar family famous fan fancy fantastic farm farmer fashion fast faster fat
+fatal father fault favor favorite favour fear feasible feather feature february
+fed federated fee feed feedback feel feet fell felt female fence festival fetch
+fetcher fever few fiction fictional fiddly field fifth fight figure file
+filename filesystem fill filler film filter final finally finance find fine
+finger fingerprint finish fire firewall firmware first firstly fish fist fit
+five fix fixed fixture fixup flag flaky flat fled flew flexible flicker flight
+float floating flood floor flour flow flower flown fluent flush fly focus
+focused fog fold folder folding follow following font food fool foot football
+footer for forbade forbidden force forecast foreground foreign forest forgave
+forget forgive forgiven forgot forgotten fork form formal format formatted
+formatter formatting formerly formula fortunate fortunately fortune forum
+forward fought found foundation four fourth fox fraction fragile fragment frame
+framework frankly free freedom freeze freezer frequency frequent frequently
+fresh freshly friction friday fridge friend friendly frighten frog from front
+frontend frontmatter froze frozen fruit frustrating fuel fulfill full fully fun
+function functional functionality fundamental funeral funny fur furniture
+further furthermore future fuzz fuzzy gain game gap garage garbage garden gas
+gate gateway gather gauge gave gender general generally generate generation
+generator generic generous gentle gentleman genuine geometry get getter ghost
+giant gift gigantic girl git given glad glass glitch glob global glossary glove
+glue glyph go goal goat god gold golden golf gone good got gotten govern
+governance government grab graceful gracefully grade gradual gradually grain
+grammar grand grandfather grandmother grant granular granularity grape graph
+graphical grass grateful gratuitous grave gray grayscale great greater greatly
+greedy green greet grew grey grid ground group grouping grow grown growth
+guarantee guard guess guest guidance guide guideline guilty guitar gun guy
+habit had hadn't hair half hall halt hammer hand handful handle handler
+handshake handy hang happen happiness happy harbor harbour hard hardcode
+hardening hardly hardware harmless harness has hash hasn't hat hate have
+haven't having hazard he head header heading headless health heap hear heard
+heart heat heaven heavily heavy heel height held hell hello help helper helpful
+hence her here here's hero hers heuristic hexadecimal hid hidden hide
+hierarchical hierarchy high highlight highway hill him hint hire his historic
+historical history hit hobby hold hole holiday holistic hollow holy home
+homepage honest honey honor hook hope hopefully horizontal horizontally horror
+horse hospital host hosting hostname hot hotel hotfix hour house housing hover
+how however html http https hug huge human hundred hung hunger hungry hunt
+hurry hurt husband hybrid hyphen hyphenate i i'd i'll i'm i've ice icon id idea
+ideal ideally idempotent identical identifier identify identity idiomatic idle
+if ignorable ignore ill illegal illness illustrate image imagine immediate
+immediately imminent immutable impact impersonate implement implementation
+implementer implication implicit implicitly import important importantly
+impossible impractical imprecise impression improve improvement in inaccurate
+inactive inadvertent inadvertently inappropriate inbound incident incidental
+include inclusion inclusive income incoming incompatibility incompatible
+incomplete inconsistency inconsistent incorrect incorrectly increase
+increasingly increment incremental incrementally indeed indefinite indefinitely
+indent indentation independent index indexer indexes indicate indication
+indicator indices indirect indirectly individual individually industry
+inefficient inevitable inexpensive infant infinite influence info inform
+informal information informational infrastructure ingest inherit inheritance
+initial initialization initialize initializer initially inject injection injure
+injury ink inline inner innocent innocuous input insect insecure insert inside
+insight inspect inspection inspire install installer instance instant
+instantiate instead instruction insufficient insurance int integer integrate
+integration integrity intelligent intend intensive intent intention intentional
+intentionally interact interaction interactive interception interchangeable
+interest interface interim intermediate intermittent intermittently internal
+internally interoperability interpolate interpolation interpret interrupt
+interval into introduce intuitive invalid invalidate invalidation invariant
+invent inventory inverse invert invest investigate invisible invite invocation
+invoke involve iron irrelevant irreversible is island isn't isolate isolation
+issue it it'd it's item iterate iteration iteratively iterator its itself
+jacket jail jam january jeans jewel jitter job join joke journal journey joy
+json judge judgment juice july jump june jungle junior just justice justify
+keep kept kernel key keyboard keystroke keyword kick kid kill kind kiss kitchen
+kludge knee knew knife knock know knowledge known lab label lack lady lag laid
+lake lamp land landing landscape language large largely last lasting late
+lately latency later latest latter laugh launch launcher law lawyer lay layer
+layout lazy lead leader leading leaf leak lean leaner learn least leather leave
+lecture led left leg legacy legible legitimate lend length lengthy lenient lent
+less lesson let let's letter level lexical lexicographic liberal liberty
+library license lid lie life lifecycle lifespan lifetime lift light lighter
+lightweight like likelihood likely likewise limit limitation line linear
+linearly link linkage lint linter lip liquid list listen listener listing
+literal literally little live livelock lively load loader loan local locale
+locally locate location lock log logger logic logical login logout lonely long
+longer look lookup loop loose loosely lord lose loss lossless lossy lost lot
+lovely low lower lowercase loyal luck lucky lunch machine macro mad made
+magazine magic magnitude mail main mainly maintain maintainable maintainer
+maintenance major make male malformed malicious mall man manage manageable
+management manager mandate mandatory manifest manipulate manner manual manually
+many map mapping march margin marginal mark markdown marker market marriage
+marry mask mass massive master masthead match mate material matrices matrix
+matter mature max maximal maximum may maybe me meal mean meaning meaningful
+meaningless meant meanwhile measurable measure meat mechanism media medicine
+medium meet meeting member memoize memorable memory men mental mention menu
+merely merge mergeable mess message messy met meta metadata metal metaphor
+method meticulous metric mice middle middleware midnight might migrate
+migration mile milestone milk million milliseconds mind mindful mine minified
+minimal minimize minimum minister minor minute mirror misbehave misc
+miscellaneous misconfiguration misleading mismatch misnamed misplaced miss
+missing misspell misspelling mistake mistaken mistakenly mistook misuse
+mitigate mitigation mix mixture mobile mock modal mode model moderate modern
+modest modify modular module modulo mom moment monday money monitor monitoring
+monkey monolithic monorepo monotonic monster month monthly mood moon moral more
+moreover morning most mostly mother motor mount mountain mouse mouth move movie
+much mud multi multiline multiple multiplex multiply murder muscle museum music
+must mustn't mutable mutate mutation mutex mutual my mystery nail naive name
+namely namespace naming narrow nation native natural naturally nature navigate
+navigation navigational near nearby nearly necessarily necessary necessity neck
+need needle needn't negative negligible neighbor neighbour nephew nerve nervous
+nest nested network never nevertheless new newer newest newline news newspaper
+next nice nicer niece night nightly nine nit no noble node noise noisy nominal
+non none nonetheless noop nor normal normalization normalize normally north
+nose not notable notably notation note nothing notice noticeable notification
+notify novel november now nuance null nullable number numeric numerous nurse
+nut obey object objective obligatory obscure observability observable observe
+observer obsolete obtain obvious obviously occasionally occupancy occur
+occurrence ocean october odd of off offend offending offer office officer
+offline offload offset often oil okay old omit on onboarding once one ongoing
+online only onto opaque open opera operate operation operational operator
+opinion opinionated opportunity opposite opt optimal optimistic optimization
+optimize option optional optionally or orange orchestrate orchestration order
+orderly ordinary org organ organization organize orientation origin original
+originally orphan other otherwise ought our ours out outage outbound outcome
+outdated outer outline output outright outside outstanding oven over overall
+overdue overflow overhead overlap overlay overload overly overridden override
+overrode oversight overview overwrite own owner ownership pack package pad
+padding page paginate pagination paid pain painful paint painting pair palace
+pale palette pan pane panel panic paper parade paradigm paragraph parallel
+parallelism parallelize param parameter parent parenthesis parenthesize parity
+park parking parsable parse parser part partial partially participant
+particular particularly partition partly partner party pass passenger passion
+passive password past pasta paste patch path patient pattern pause pay payload
+payment peace peculiar pedantic peer pen penalty pencil pending people pepper
+per percent percentage perceptible perfect perform performance perhaps period
+periodic periodically peripheral permanent permanently permission permissive
+permit perpetual persist persistence persistent person personal perspective
+pessimistic pet phase phenomena phone photo photograph phrase physical piano
+pick picture pie piece pig pile pill pilot pin pink pinned pipe pipeline pity
+pixel place placeholder placement plain plan plane planet plant plastic plate
+platform plausible play player pleasant please pleasure pluggable plugin plural
+pluralize plus pocket poem poet point pointer poison police policy polish
+polite politics poll pool poor pop popular populate population pork port
+portability portable portion position positional positive possible possibly
+post postpone pot potato potential potentially pound pour poverty powder power
+practical practice pragmatic praise pray prayer pre preamble precaution precede
+precedence precedent precise precision precompute precondition predefined
+predicate predictable prefer preferable preferably preference prefetch prefix
+pregnant preliminary premature prematurely prepare prerelease prerequisite
+presence present presentation preserve president press pressure presumably
+pretend pretty prevalent prevent prevention preview previous previously price
+pride priest primarily primary primitive prince princess principal principle
+print printable prior priority prison privacy private privilege prize proactive
+probably probe problem problematic procedure proceed process processor produce
+product production productive profanity professional profile profiler profiling
+profit program programmatic progress prohibit project prominent promise promote
+promotion prompt promptly proof propagate proper properly property proportion
+proposal propose proprietary protect protection protective protocol prototype
+proud prove provide provider provisional proximity proxy prune pseudo pub
+public publish pull punctuation punish pupil purely purge purple purpose purse
+push put qualified qualify quality quantity quarter quarterly queen query
+question queue quick quickly quiet quirk quirky quite quota quote rabbit race
+racy radio radix rail rain raise ran random range rank rare rarely rat rate
+rather rationale raw re reach react read readability readable reader readily
+readiness readme ready real realistic realize really rearrange reason
+reasonable reasonably reasoning reassign rebalance rebase rebuild rebuilt
+receive recent recently recipe recipient recognize recommend reconcile
+reconnect reconsider record recover recovery recurring recursion recursive
+recursively red redact redesign redirect redone reduce redundant reentrant ref
+refactor refactoring refer reference referral refine reflect reformat refresh
+refund refuse regard regardless regenerate regex region register registration
+registry regression regressive regular regularly reimplement reindex reinstall
+reintroduce reject relate relation relationship relative relatively relax
+relaxed relay release relevant reliability reliable reliant religion reload
+relocate reluctant rely remain remainder remedy reminder remote removal remove
+rename render renewal rent reopen reorder repair repeat repeatable repeatedly
+rephrase replace replacement replay replica replicate reply repo report
+repository represent representation reproduce reproducible reputation request
+requester require requirement reran rerun rescue reservation reserve reset
+reside residual resilience resilient resize resolution resolve resolver
+resource respect respective respectively respond response responsibility
+responsible responsive rest restart restaurant restore restrict restriction
+restructure result resumable resume retain retention retrieve retroactive retry
+return reusable reuse revamp reveal reversal reverse revert review revise
+revision revoke reward reword rewrite rewritten rewrote rice rich ride right
+rigid rigorous ring rise risk risky river road robust robustness rock rode role
+roll rollback rollout roof room root rope rose rotate rotation rough roughly
+round roundtrip route router routine routing row royal rubber rude rudimentary
+ruin rule run runner runtime rush sad safe safely safety said sail salad salary
+sale salt same sample sand sandbox sandwich sane sang sanitize sanity sank sat
+satisfy saturday sauce save saw say scaffold scaffolding scalability scalable
+scale scan scarce scare scattered scenario scene schedule scheduler schema
+scheme school science scientist scope score scratch screen script scroll sea
+seamless seamlessly search searchable season seasonal seat second secondary
+secondly secret secretary section secure security see seed seek seem seemingly
+seen segment seldom select selection selector self sell semantic semantics semi
+semver send senior sense sensible sensitive sensitivity sent sentence sentinel
+separate separately separator september sequence sequential serial serialize
+serializer series serious servant serve server serverless service session set
+setter setting settings settle setup seven several severe severity sex shade
+shadow shake shallow shame shape share shared sharper she sheep sheet shelf
+shell shift shim shine ship shirt shock shoe shook shoot shop shopping shore
+short shortcut shorthand shortly shot should shoulder shouldn't shout show
+shower shown shrink shut shutdown sibling sick side sidebar sidecar sight sign
+signal signature significant significantly signup silent silently silk silly
+silver similar similarly simple simplicity simplify simply simultaneous
+simultaneously since sing singer single singleton singular sink sister sit site
+situation six size skeleton sketchy skill skin skip skirt sky slack slash sleep
+slept slice slid slight slightly sloppy slot slow slowly sluggish small smart
+smell smile smoke smooth snake snapshot snippet snow so soap soccer society
+sock socket soft software soil sold soldier sole solely solid solution solve
+some somehow someone something sometimes somewhat somewhere son song soon
+sophisticated sorry sort sortable sought soul soup source south space span
+spare sparse spawn speak spec special specialize specific specifically
+specification specify speed spell spelling spend spent spider spike spinner
+spirit split spoke spoken spoon sport spot spread spring spun spurious sql
+square squash stabilize stable stack staff stage staging stair stale stamp
+stand standalone standard star start startup stash state stateful stateless
+statement static station statistic statistics status stay stderr stdin stdout
+steady steal steam steel step stick sticky still stole stolen stomach stone
+stood stop stopgap storage store storm story storybook stove straight
+straightforward strange stranger strategy stream streamline street strength
+stress stretch strict stricter strictly string stringify strip strong struck
+struct structure stub stubborn stuck student study stuff stupid style sub
+subclass subcommand subdirectory subject submit submodule subscribe subscriber
+subscription subsequent subsequently subset substantial substitute substring
+subsystem subtle subtract succeed success successful successfully successor
+succinct such suddenly sudo suffer sufficient sufficiently suffix sugar suggest
+suggestion suit suitable sum summary summer sun sunday sunk super superclass
+superfluous supersede supervisor supper supplementary supply support suppose
+supposedly suppress suppression sure surely surface surgery surplus surprise
+surround survey survive susceptible suspect suspend suspicious sustainable
+swallow swap swear sweat sweet swept swim switch sword swore symbol symbolic
+symlink symmetric symptom sync synchronize synchronous synonym syntax synthetic
+system systematic tab table tabular tag tail take taken tall tangential tape
+target task taste taught tax taxi tea teach teacher team tear teardown
+technical tedious teeth telemetry telephone television temp temperature
+template temple temporarily temporary ten tennis tent tentative term terminal
+terminate terrible territory terse test testing text textual than thank that
+that's the theater theatre their theirs them theme themselves then theoretical
+there there's thereby therefore these they they're they've thick thief thin
+thing think third thirsty this thorough thoroughly those though thought
+thoughtful thousand thread threat three threshold threw throat throttle through
+throughout throughput throw thrown thumb thursday thus ticket tidy tie tiger
+tight time timeout timer timestamp timezone tiny tip tired title to today toe
+together toggle toilet token told tolerance tolerant tomato tomorrow tone
+tongue tonight took tool toolchain tooltip tooth top topic topology tore torn
+total totally touch tour tower town toy trace track trade tradeoff traditional
+traffic trailer trailing train training transaction transactional transfer
+transform transient transition transitive translate translation transparency
+transparent transport trap travel traverse treasure treat treatment tree
+tremendous triage trial trick trickier tricky trigger trim trip triple trivial
+trouble troubleshoot troublesome truck true truly truncate trust truth truthy
+try tube tuesday tune tuple turn tutorial tweak twelve twice twin two type
+typed typesafe typical typically typo ugly ui ultimately umbrella unable
+unambiguous unary unauthorized unavailable unaware unblock unbounded uncaught
+unchanged uncle unclear uncommon unconditional unconditionally undeclared
+undefined under underlying underscore understand understood undesirable undid
+undo undocumented undone unescape unexpected unexpectedly unexported
+unfortunately unhandled unicode uniform unify uninstall unintended
+unintentional unintentionally union unique unit unitless universal university
+unknown unless unlike unlikely unlimited unlock unmarshal unnecessarily
+unnecessary unpredictable unreachable unreadable unrelated unreleased
+unresolved unresponsive unsafe unset unsigned unsorted unsound unspecified
+unstable unsupported untested until untracked unused unusual unwanted unwrap up
+upcoming update upfront upgrade upheld upload upon upper uppercase upset
+upstream urban urgency urgent url us usability usable usage use useful useless
+user username usual usually utf utility utilize vacation vague valid validate
+validation validator validity valley valuable value var variable variadic
+variant variation various vary vastly vector vegetable vehicle vendor verbatim
+verbose verbosity verification verify versa versatile version versioning versus
+vertical vertically vertices very via viable vice victim victory video view
+vigilant village violate violation violence virtual virtually virus visibility
+visible visit visitor visual vital voice void volume vote vs vulnerability
+vulnerable wage waist wait waive wake walk wall wallet want war warm warn
+warning was wash wasn't waste wasteful watch watcher water wave way we we're
+we've weak wealth weapon wear weather web wedding wednesday week weekend weekly
+weight weird welcome well went were weren't west wet what what's whatever wheel
+when whenever where whereas whereby wherever whether which whichever while
+white whitespace who who's whole wholly whom whose why wide widely widespread
+widget width wife wild wildcard will willing win wind window wine wing winner
+winter wipe wire wise wish with withdrew within without witness woke woman
+women won won't wonder wood wool word wording wore work workable workaround
+worker workflow workspace world worm worn worry worse worst worth worthwhile
+would wouldn't wound wrap wrapped wrapper write writer written wrong wrote xml
+yaml yard year yellow yes yesterday yet yield you you're you've young your
+yours yourself youth zero zip zone
+`